@@ -0,0 +1,196 @@
+package giu
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"math"
+	"time"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+const defaultGIFFrameDelay = 100 * time.Millisecond
+
+// Animation is a sequence of frames, uploaded once as one atlas texture.
+type Animation struct {
+	texture *Texture
+	frames  []image.Rectangle
+	delays  []time.Duration
+	total   time.Duration
+	start   time.Time
+}
+
+// NewAnimationFromGIF composes the frames of g (decoded by gif.DecodeAll) and uploads them as one texture.
+// Note: this function has to be invokded in a go routine, see NewTextureFromRgba.
+func NewAnimationFromGIF(g *gif.GIF) (*Animation, error) {
+	if len(g.Image) == 0 {
+		return nil, errors.New("gif has no frames")
+	}
+
+	width, height := g.Config.Width, g.Config.Height
+	if width == 0 || height == 0 {
+		bounds := g.Image[0].Bounds()
+		width, height = bounds.Max.X, bounds.Max.Y
+	}
+
+	// Lay frames out in a grid, so the atlas doesn't exceed texture size limits as quickly as a strip.
+	count := len(g.Image)
+	cols := int(math.Ceil(math.Sqrt(float64(count))))
+	rows := (count + cols - 1) / cols
+	atlas := image.NewRGBA(image.Rect(0, 0, cols*width, rows*height))
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	previous := image.NewRGBA(canvas.Bounds())
+	frames := make([]image.Rectangle, count)
+	delays := make([]time.Duration, count)
+
+	for i, frame := range g.Image {
+		disposal := byte(gif.DisposalNone)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			copy(previous.Pix, canvas.Pix)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		cell := image.Rect(0, 0, width, height).Add(image.Pt(i%cols*width, i/cols*height))
+		draw.Draw(atlas, cell, canvas, image.Point{}, draw.Src)
+		frames[i] = cell
+
+		delays[i] = defaultGIFFrameDelay
+		if i < len(g.Delay) && g.Delay[i] > 0 {
+			delays[i] = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+		}
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			copy(canvas.Pix, previous.Pix)
+		}
+	}
+
+	return newAnimation(atlas, frames, delays)
+}
+
+// NewAnimationFromSpriteSheet uploads sheet as one texture and plays frames, rectangles in
+// the sheet, one after another with frameDelay between them.
+// Note: this function has to be invokded in a go routine, see NewTextureFromRgba.
+func NewAnimationFromSpriteSheet(sheet *image.RGBA, frames []image.Rectangle, frameDelay time.Duration) (*Animation, error) {
+	if len(frames) == 0 {
+		return nil, errors.New("sprite sheet has no frames")
+	}
+
+	delays := make([]time.Duration, len(frames))
+	for i := range delays {
+		delays[i] = frameDelay
+	}
+
+	// Frame rects are relative to the texture, which starts at the origin.
+	offset := sheet.Bounds().Min
+	relative := make([]image.Rectangle, len(frames))
+	for i, f := range frames {
+		relative[i] = f.Sub(offset)
+	}
+
+	return newAnimation(sheet, relative, delays)
+}
+
+func newAnimation(atlas *image.RGBA, frames []image.Rectangle, delays []time.Duration) (*Animation, error) {
+	texture, err := NewTextureFromRgba(atlas)
+	if err != nil {
+		return nil, err
+	}
+
+	var total time.Duration
+	for _, d := range delays {
+		total += d
+	}
+
+	return &Animation{
+		texture: texture,
+		frames:  frames,
+		delays:  delays,
+		total:   total,
+		start:   time.Now(),
+	}, nil
+}
+
+// FrameCount returns the count of frames.
+func (a *Animation) FrameCount() int {
+	return len(a.frames)
+}
+
+// Restart plays the animation from its first frame.
+func (a *Animation) Restart() {
+	a.start = time.Now()
+}
+
+// Release releases the texture of the animation, see Texture.Release.
+func (a *Animation) Release() {
+	a.texture.Release()
+}
+
+// currentFrame returns the frame to show now and how long it remains.
+func (a *Animation) currentFrame() (int, time.Duration) {
+	if a.total <= 0 {
+		return 0, 0
+	}
+
+	elapsed := time.Since(a.start) % a.total
+	for i, d := range a.delays {
+		if elapsed < d {
+			return i, d - elapsed
+		}
+		elapsed -= d
+	}
+	return len(a.delays) - 1, 0
+}
+
+type AnimatedImageWidget struct {
+	animation *Animation
+	width     float32
+	height    float32
+}
+
+// AnimatedImage shows the current frame of animation, frames advance by wall clock.
+// In power saving mode the next frame is scheduled when the current one ends, so idle
+// frames in between are not rendered.
+func AnimatedImage(animation *Animation, width, height float32) *AnimatedImageWidget {
+	return &AnimatedImageWidget{
+		animation: animation,
+		width:     width,
+		height:    height,
+	}
+}
+
+func (a *AnimatedImageWidget) Build() {
+	if a.animation == nil || a.animation.texture.id == 0 {
+		return
+	}
+
+	index, remaining := a.animation.currentFrame()
+	if len(a.animation.frames) > 1 {
+		imgui.SetMaxWaitBeforeNextFrame(float32(remaining.Seconds()))
+	}
+
+	frame := a.animation.frames[index]
+	texWidth, texHeight := a.animation.texture.Size()
+	uv0 := imgui.Vec2{X: float32(frame.Min.X) / float32(texWidth), Y: float32(frame.Min.Y) / float32(texHeight)}
+	uv1 := imgui.Vec2{X: float32(frame.Max.X) / float32(texWidth), Y: float32(frame.Max.Y) / float32(texHeight)}
+
+	size := imgui.Vec2{X: a.width, Y: a.height}
+	rect := imgui.ContentRegionAvail()
+	if size.X == -1 {
+		size.X = rect.X
+	}
+	if size.Y == -1 {
+		size.Y = rect.Y
+	}
+
+	imgui.ImageV(a.animation.texture.id, size, uv0, uv1, imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}, imgui.Vec4{})
+}
@@ -21,6 +21,11 @@ func (c *Canvas) AddLine(p1, p2 image.Point, color color.RGBA, thickness float32
 	c.drawlist.AddLine(ToVec2(p1), ToVec2(p2), ToVec4Color(color), thickness)
 }
 
+// AddLineF works like AddLine with float coordinates.
+func (c *Canvas) AddLineF(p1, p2 imgui.Vec2, color color.RGBA, thickness float32) {
+	c.drawlist.AddLine(p1, p2, ToVec4Color(color), thickness)
+}
+
 type CornerFlags int
 
 const (
@@ -41,42 +46,207 @@ func (c *Canvas) AddRect(pMin, pMax image.Point, color color.RGBA, rounding floa
 	c.drawlist.AddRect(ToVec2(pMin), ToVec2(pMax), ToVec4Color(color), rounding, int(rounding_corners), thickness)
 }
 
+// AddRectF works like AddRect with float coordinates.
+func (c *Canvas) AddRectF(pMin, pMax imgui.Vec2, color color.RGBA, rounding float32, rounding_corners CornerFlags, thickness float32) {
+	c.drawlist.AddRect(pMin, pMax, ToVec4Color(color), rounding, int(rounding_corners), thickness)
+}
+
 func (c *Canvas) AddRectFilled(pMin, pMax image.Point, color color.RGBA, rounding float32, rounding_corners CornerFlags) {
 	c.drawlist.AddRectFilled(ToVec2(pMin), ToVec2(pMax), ToVec4Color(color), rounding, int(rounding_corners))
 }
 
+// AddRectFilledF works like AddRectFilled with float coordinates.
+func (c *Canvas) AddRectFilledF(pMin, pMax imgui.Vec2, color color.RGBA, rounding float32, rounding_corners CornerFlags) {
+	c.drawlist.AddRectFilled(pMin, pMax, ToVec4Color(color), rounding, int(rounding_corners))
+}
+
+// AddRectFilledMultiColor fills a rectangle with a gradient between the colors of its corners.
+func (c *Canvas) AddRectFilledMultiColor(pMin, pMax image.Point, colUpperLeft, colUpperRight, colBottomRight, colBottomLeft color.RGBA) {
+	c.AddRectFilledMultiColorF(ToVec2(pMin), ToVec2(pMax), colUpperLeft, colUpperRight, colBottomRight, colBottomLeft)
+}
+
+// AddRectFilledMultiColorF works like AddRectFilledMultiColor with float coordinates.
+func (c *Canvas) AddRectFilledMultiColorF(pMin, pMax imgui.Vec2, colUpperLeft, colUpperRight, colBottomRight, colBottomLeft color.RGBA) {
+	c.drawlist.AddRectFilledMultiColor(pMin, pMax, ToVec4Color(colUpperLeft), ToVec4Color(colUpperRight), ToVec4Color(colBottomRight), ToVec4Color(colBottomLeft))
+}
+
 func (c *Canvas) AddText(pos image.Point, color color.RGBA, text string) {
 	c.drawlist.AddText(ToVec2(pos), ToVec4Color(color), text)
 }
 
+// AddTextF works like AddText with float coordinates.
+func (c *Canvas) AddTextF(pos imgui.Vec2, color color.RGBA, text string) {
+	c.drawlist.AddText(pos, ToVec4Color(color), text)
+}
+
+// AddTextV draws text with font at size, nil and 0 use the current font and its size.
+// Text is wrapped at wrapWidth if it's > 0, and glyphs are clipped to cpuFineClipRect (minX, minY, maxX, maxY) if it's not nil.
+func (c *Canvas) AddTextV(font *imgui.Font, size float32, pos image.Point, color color.RGBA, text string, wrapWidth float32, cpuFineClipRect *imgui.Vec4) {
+	c.AddTextVF(font, size, ToVec2(pos), color, text, wrapWidth, cpuFineClipRect)
+}
+
+// AddTextVF works like AddTextV with float coordinates.
+func (c *Canvas) AddTextVF(font *imgui.Font, size float32, pos imgui.Vec2, color color.RGBA, text string, wrapWidth float32, cpuFineClipRect *imgui.Vec4) {
+	f := imgui.DefaultFont
+	if font != nil {
+		f = *font
+	}
+	c.drawlist.AddTextV(f, size, pos, ToVec4Color(color), text, wrapWidth, cpuFineClipRect)
+}
+
+// AddTextRotated draws text rotated by angle (radians, clockwise) around pos, its upper left corner,
+// e.g. -math.Pi/2 for vertical axis labels.
+// Note: glyphs are culled before rotating, text starting outside the window isn't drawn.
+func (c *Canvas) AddTextRotated(font *imgui.Font, size float32, pos image.Point, angle float32, color color.RGBA, text string) {
+	c.AddTextRotatedF(font, size, ToVec2(pos), angle, color, text)
+}
+
+// AddTextRotatedF works like AddTextRotated with float coordinates.
+func (c *Canvas) AddTextRotatedF(font *imgui.Font, size float32, pos imgui.Vec2, angle float32, color color.RGBA, text string) {
+	start := c.drawlist.VertexCount()
+	c.AddTextVF(font, size, pos, color, text, 0, nil)
+	c.drawlist.RotateVertices(start, angle, pos)
+}
+
 func (c *Canvas) AddBezierCurve(pos0, cp0, cp1, pos1 image.Point, color color.RGBA, thickness float32, num_segments int) {
 	c.drawlist.AddBezierCurve(ToVec2(pos0), ToVec2(cp0), ToVec2(cp1), ToVec2(pos1), ToVec4Color(color), thickness, num_segments)
 }
 
+// AddBezierCurveF works like AddBezierCurve with float coordinates.
+func (c *Canvas) AddBezierCurveF(pos0, cp0, cp1, pos1 imgui.Vec2, color color.RGBA, thickness float32, num_segments int) {
+	c.drawlist.AddBezierCurve(pos0, cp0, cp1, pos1, ToVec4Color(color), thickness, num_segments)
+}
+
 func (c *Canvas) AddTriangle(p1, p2, p3 image.Point, color color.RGBA, thickness float32) {
 	c.drawlist.AddTriangle(ToVec2(p1), ToVec2(p2), ToVec2(p3), ToVec4Color(color), thickness)
 }
 
+// AddTriangleF works like AddTriangle with float coordinates.
+func (c *Canvas) AddTriangleF(p1, p2, p3 imgui.Vec2, color color.RGBA, thickness float32) {
+	c.drawlist.AddTriangle(p1, p2, p3, ToVec4Color(color), thickness)
+}
+
 func (c *Canvas) AddTriangleFilled(p1, p2, p3 image.Point, color color.RGBA) {
 	c.drawlist.AddTriangleFilled(ToVec2(p1), ToVec2(p2), ToVec2(p3), ToVec4Color(color))
 }
 
+// AddTriangleFilledF works like AddTriangleFilled with float coordinates.
+func (c *Canvas) AddTriangleFilledF(p1, p2, p3 imgui.Vec2, color color.RGBA) {
+	c.drawlist.AddTriangleFilled(p1, p2, p3, ToVec4Color(color))
+}
+
 func (c *Canvas) AddCircle(center image.Point, radius float32, color color.RGBA, num_segments int, thickness float32) {
 	c.drawlist.AddCircle(ToVec2(center), radius, ToVec4Color(color), num_segments, thickness)
 }
 
+// AddCircleF works like AddCircle with float coordinates.
+func (c *Canvas) AddCircleF(center imgui.Vec2, radius float32, color color.RGBA, num_segments int, thickness float32) {
+	c.drawlist.AddCircle(center, radius, ToVec4Color(color), num_segments, thickness)
+}
+
 func (c *Canvas) AddCircleFilled(center image.Point, radius float32, color color.RGBA, num_segments int) {
 	c.drawlist.AddCircleFilled(ToVec2(center), radius, ToVec4Color(color), num_segments)
 }
 
+// AddCircleFilledF works like AddCircleFilled with float coordinates.
+func (c *Canvas) AddCircleFilledF(center imgui.Vec2, radius float32, color color.RGBA, num_segments int) {
+	c.drawlist.AddCircleFilled(center, radius, ToVec4Color(color), num_segments)
+}
+
 func (c *Canvas) AddQuad(p1, p2, p3, p4 image.Point, color color.RGBA, thickness float32) {
 	c.drawlist.AddQuad(ToVec2(p1), ToVec2(p2), ToVec2(p3), ToVec2(p4), ToVec4Color(color), thickness)
 }
 
+// AddQuadF works like AddQuad with float coordinates.
+func (c *Canvas) AddQuadF(p1, p2, p3, p4 imgui.Vec2, color color.RGBA, thickness float32) {
+	c.drawlist.AddQuad(p1, p2, p3, p4, ToVec4Color(color), thickness)
+}
+
 func (c *Canvas) AddQuadFilled(p1, p2, p3, p4 image.Point, color color.RGBA) {
 	c.drawlist.AddQuadFilled(ToVec2(p1), ToVec2(p2), ToVec2(p3), ToVec2(p4), ToVec4Color(color))
 }
 
+// AddQuadFilledF works like AddQuadFilled with float coordinates.
+func (c *Canvas) AddQuadFilledF(p1, p2, p3, p4 imgui.Vec2, color color.RGBA) {
+	c.drawlist.AddQuadFilled(p1, p2, p3, p4, ToVec4Color(color))
+}
+
+// AddPolyline draws lines through points, closed connects the last point with the first one.
+func (c *Canvas) AddPolyline(points []image.Point, color color.RGBA, closed bool, thickness float32) {
+	c.AddPolylineF(toVec2s(points), color, closed, thickness)
+}
+
+// AddPolylineF works like AddPolyline with float coordinates.
+func (c *Canvas) AddPolylineF(points []imgui.Vec2, color color.RGBA, closed bool, thickness float32) {
+	c.drawlist.AddPolyline(points, ToVec4Color(color), closed, thickness)
+}
+
+// AddConvexPolyFilled fills the convex polygon described by points.
+func (c *Canvas) AddConvexPolyFilled(points []image.Point, color color.RGBA) {
+	c.AddConvexPolyFilledF(toVec2s(points), color)
+}
+
+// AddConvexPolyFilledF works like AddConvexPolyFilled with float coordinates.
+func (c *Canvas) AddConvexPolyFilledF(points []imgui.Vec2, color color.RGBA) {
+	c.drawlist.AddConvexPolyFilled(points, ToVec4Color(color))
+}
+
+// AddImage draws texture into the rectangle pMin-pMax.
+func (c *Canvas) AddImage(texture *Texture, pMin, pMax image.Point) {
+	c.AddImageV(texture, pMin, pMax, imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 1}, color.RGBA{255, 255, 255, 255})
+}
+
+// AddImageV draws the part of texture between uvMin and uvMax into the rectangle pMin-pMax, tinted with color.
+func (c *Canvas) AddImageV(texture *Texture, pMin, pMax image.Point, uvMin, uvMax imgui.Vec2, color color.RGBA) {
+	c.AddImageF(texture, ToVec2(pMin), ToVec2(pMax), uvMin, uvMax, color)
+}
+
+// AddImageF works like AddImageV with float coordinates.
+func (c *Canvas) AddImageF(texture *Texture, pMin, pMax, uvMin, uvMax imgui.Vec2, color color.RGBA) {
+	if texture == nil || texture.id == 0 {
+		return
+	}
+	c.drawlist.AddImage(texture.id, pMin, pMax, uvMin, uvMax, ToVec4Color(color))
+}
+
+// AddImageQuadF draws texture into the quad p1-p4, e.g. to rotate or skew it.
+// uv1-uv4 are the texture coordinates of the corners.
+func (c *Canvas) AddImageQuadF(texture *Texture, p1, p2, p3, p4, uv1, uv2, uv3, uv4 imgui.Vec2, color color.RGBA) {
+	if texture == nil || texture.id == 0 {
+		return
+	}
+	c.drawlist.AddImageQuad(texture.id, p1, p2, p3, p4, uv1, uv2, uv3, uv4, ToVec4Color(color))
+}
+
+// PushClipRect clips following drawing to the rectangle pMin-pMax until PopClipRect is called.
+// intersectWithCurrent limits it to the current clip rectangle, e.g. the one of the window.
+func (c *Canvas) PushClipRect(pMin, pMax image.Point, intersectWithCurrent bool) {
+	c.PushClipRectF(ToVec2(pMin), ToVec2(pMax), intersectWithCurrent)
+}
+
+// PushClipRectF works like PushClipRect with float coordinates.
+func (c *Canvas) PushClipRectF(pMin, pMax imgui.Vec2, intersectWithCurrent bool) {
+	c.drawlist.PushClipRect(pMin, pMax, intersectWithCurrent)
+}
+
+func (c *Canvas) PopClipRect() {
+	c.drawlist.PopClipRect()
+}
+
+// ChannelsSplit splits drawing into count channels, which are drawn in order by ChannelsMerge.
+// Select the channel to draw into with ChannelsSetCurrent, e.g. to draw a background after its content.
+func (c *Canvas) ChannelsSplit(count int) {
+	c.drawlist.ChannelsSplit(count)
+}
+
+func (c *Canvas) ChannelsSetCurrent(n int) {
+	c.drawlist.ChannelsSetCurrent(n)
+}
+
+func (c *Canvas) ChannelsMerge() {
+	c.drawlist.ChannelsMerge()
+}
+
 // Stateful path API, add points then finish with PathFillConvex() or PathStroke()
 
 func (c *Canvas) PathClear() {
@@ -87,10 +257,18 @@ func (c *Canvas) PathLineTo(pos image.Point) {
 	c.drawlist.PathLineTo(ToVec2(pos))
 }
 
+func (c *Canvas) PathLineToF(pos imgui.Vec2) {
+	c.drawlist.PathLineTo(pos)
+}
+
 func (c *Canvas) PathLineToMergeDuplicate(pos image.Point) {
 	c.drawlist.PathLineToMergeDuplicate(ToVec2(pos))
 }
 
+func (c *Canvas) PathLineToMergeDuplicateF(pos imgui.Vec2) {
+	c.drawlist.PathLineToMergeDuplicate(pos)
+}
+
 func (c *Canvas) PathFillConvex(color color.RGBA) {
 	c.drawlist.PathFillConvex(ToVec4Color(color))
 }
@@ -103,10 +281,30 @@ func (c *Canvas) PathArcTo(center image.Point, radius, a_min, a_max float32, num
 	c.drawlist.PathArcTo(ToVec2(center), radius, a_min, a_max, num_segments)
 }
 
+func (c *Canvas) PathArcToF(center imgui.Vec2, radius, a_min, a_max float32, num_segments int) {
+	c.drawlist.PathArcTo(center, radius, a_min, a_max, num_segments)
+}
+
 func (c *Canvas) PathArcToFast(center image.Point, radius float32, a_min_of_12, a_max_of_12 int) {
 	c.drawlist.PathArcToFast(ToVec2(center), radius, a_min_of_12, a_max_of_12)
 }
 
+func (c *Canvas) PathArcToFastF(center imgui.Vec2, radius float32, a_min_of_12, a_max_of_12 int) {
+	c.drawlist.PathArcToFast(center, radius, a_min_of_12, a_max_of_12)
+}
+
 func (c *Canvas) PathBezierCurveTo(p1, p2, p3 image.Point, num_segments int) {
 	c.drawlist.PathBezierCurveTo(ToVec2(p1), ToVec2(p2), ToVec2(p3), num_segments)
 }
+
+func (c *Canvas) PathBezierCurveToF(p1, p2, p3 imgui.Vec2, num_segments int) {
+	c.drawlist.PathBezierCurveTo(p1, p2, p3, num_segments)
+}
+
+func toVec2s(points []image.Point) []imgui.Vec2 {
+	result := make([]imgui.Vec2, len(points))
+	for i, p := range points {
+		result[i] = ToVec2(p)
+	}
+	return result
+}
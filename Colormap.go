@@ -0,0 +1,80 @@
+package giu
+
+import (
+	"image/color"
+	"math"
+)
+
+// Colormap maps values between 0 and 1 to colors, interpolating between evenly spaced stops.
+type Colormap struct {
+	stops []color.RGBA
+}
+
+// NewColormap creates a colormap going through stops, the first one is at 0 and the last one at 1.
+func NewColormap(stops ...color.RGBA) *Colormap {
+	if len(stops) == 0 {
+		stops = []color.RGBA{{0, 0, 0, 255}}
+	}
+	return &Colormap{stops: stops}
+}
+
+var (
+	// ColormapViridis goes from dark blue over green to yellow, and is readable with color blindness.
+	ColormapViridis = NewColormap(
+		color.RGBA{68, 1, 84, 255},
+		color.RGBA{71, 45, 123, 255},
+		color.RGBA{59, 82, 139, 255},
+		color.RGBA{44, 114, 142, 255},
+		color.RGBA{33, 145, 140, 255},
+		color.RGBA{40, 174, 128, 255},
+		color.RGBA{94, 201, 98, 255},
+		color.RGBA{173, 220, 48, 255},
+		color.RGBA{253, 231, 37, 255},
+	)
+	// ColormapMagma goes from black over purple and red to light yellow.
+	ColormapMagma = NewColormap(
+		color.RGBA{0, 0, 4, 255},
+		color.RGBA{24, 15, 61, 255},
+		color.RGBA{68, 15, 118, 255},
+		color.RGBA{114, 31, 129, 255},
+		color.RGBA{158, 47, 127, 255},
+		color.RGBA{205, 64, 113, 255},
+		color.RGBA{241, 96, 93, 255},
+		color.RGBA{253, 150, 104, 255},
+		color.RGBA{254, 202, 141, 255},
+		color.RGBA{252, 253, 191, 255},
+	)
+	// ColormapGrayscale goes from black to white.
+	ColormapGrayscale = NewColormap(color.RGBA{0, 0, 0, 255}, color.RGBA{255, 255, 255, 255})
+	// ColormapDiverging goes from blue over light gray to red, for values around a center like correlations.
+	ColormapDiverging = NewColormap(
+		color.RGBA{59, 76, 192, 255},
+		color.RGBA{141, 176, 254, 255},
+		color.RGBA{221, 221, 221, 255},
+		color.RGBA{244, 154, 123, 255},
+		color.RGBA{180, 4, 38, 255},
+	)
+)
+
+// At returns the color of t, which is clamped to [0, 1].
+func (c *Colormap) At(t float64) color.RGBA {
+	if math.IsNaN(t) {
+		t = 0
+	}
+	t = math.Max(0, math.Min(1, t))
+	if len(c.stops) == 1 {
+		return c.stops[0]
+	}
+
+	pos := t * float64(len(c.stops)-1)
+	i := int(pos)
+	if i >= len(c.stops)-1 {
+		return c.stops[len(c.stops)-1]
+	}
+	f := pos - float64(i)
+	a, b := c.stops[i], c.stops[i+1]
+	lerp := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*f))
+	}
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), lerp(a.A, b.A)}
+}
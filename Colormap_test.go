@@ -0,0 +1,46 @@
+package giu
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColormapAt(t *testing.T) {
+	red, green, blue := color.RGBA{255, 0, 0, 255}, color.RGBA{0, 255, 0, 255}, color.RGBA{0, 0, 255, 128}
+	colormap := NewColormap(red, green, blue)
+
+	tt := []struct {
+		t    float64
+		want color.RGBA
+	}{
+		{t: 0, want: red},
+		{t: 0.25, want: color.RGBA{128, 128, 0, 255}},
+		{t: 0.5, want: green},
+		{t: 0.75, want: color.RGBA{0, 128, 128, 192}},
+		{t: 1, want: blue},
+		{t: -1, want: red},
+		{t: 2, want: blue},
+		{t: math.Inf(1), want: blue},
+		{t: math.Inf(-1), want: red},
+		{t: math.NaN(), want: red},
+	}
+	for _, tc := range tt {
+		assert.Equal(t, tc.want, colormap.At(tc.t), "t %v", tc.t)
+	}
+}
+
+func TestColormapStops(t *testing.T) {
+	single := NewColormap(color.RGBA{1, 2, 3, 255})
+	assert.Equal(t, color.RGBA{1, 2, 3, 255}, single.At(0.5))
+
+	empty := NewColormap()
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, empty.At(0.5))
+
+	for _, colormap := range []*Colormap{ColormapViridis, ColormapMagma, ColormapGrayscale, ColormapDiverging} {
+		assert.Equal(t, colormap.stops[0], colormap.At(0))
+		assert.Equal(t, colormap.stops[len(colormap.stops)-1], colormap.At(1))
+	}
+}
@@ -9,6 +9,9 @@ var (
 type context struct {
 	renderer imgui.Renderer
 	platform imgui.Platform
+
+	scale        float32
+	contentScale float32
 }
 
 func (c context) GetRenderer() imgui.Renderer {
@@ -22,3 +25,19 @@ func (c context) GetPlatform() imgui.Platform {
 func (c context) IO() imgui.IO {
 	return imgui.CurrentIO()
 }
+
+// GetScale returns the factor the UI is scaled by for the monitor the master window is on.
+func (c context) GetScale() float32 {
+	if c.scale == 0 {
+		return 1
+	}
+	return c.scale
+}
+
+// GetContentScale returns the content scale of the monitor the master window is on.
+func (c context) GetContentScale() float32 {
+	if c.contentScale == 0 {
+		return 1
+	}
+	return c.contentScale
+}
@@ -0,0 +1,367 @@
+package giu
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// GaugeBand colors the range of values From to To on a Gauge or LinearMeter, e.g. to mark warning levels.
+// The value is drawn in the color of the band it's in.
+type GaugeBand struct {
+	From, To float64
+	Color    color.RGBA
+}
+
+// dashboardColors are the style colors dashboard widgets are drawn with.
+type dashboardColors struct {
+	text, textDisabled, frame, value color.RGBA
+}
+
+func currentDashboardColors() dashboardColors {
+	style := imgui.CurrentStyle()
+	return dashboardColors{
+		text:         Vec4ToRGBA(style.GetColor(imgui.StyleColorText)),
+		textDisabled: Vec4ToRGBA(style.GetColor(imgui.StyleColorTextDisabled)),
+		frame:        Vec4ToRGBA(style.GetColor(imgui.StyleColorFrameBg)),
+		value:        Vec4ToRGBA(style.GetColor(imgui.StyleColorPlotHistogram)),
+	}
+}
+
+// valueColor returns the color of the band value is in, or the default value color.
+func (c dashboardColors) valueColor(value float64, bands []GaugeBand) color.RGBA {
+	for _, b := range bands {
+		if value >= b.From && value <= b.To {
+			return b.Color
+		}
+	}
+	return c.value
+}
+
+// resolveSize replaces width or height -1 by the available space.
+func resolveSize(width, height float32) imgui.Vec2 {
+	size := imgui.Vec2{X: width, Y: height}
+	avail := imgui.ContentRegionAvail()
+	if size.X == -1 {
+		size.X = avail.X
+	}
+	if size.Y == -1 {
+		size.Y = avail.Y
+	}
+	return size
+}
+
+func clampFraction(value, min, max float64) float32 {
+	if max <= min {
+		return 0
+	}
+	return float32(math.Max(0, math.Min(1, (value-min)/(max-min))))
+}
+
+// addScaledText draws text with the default font scaled by scale, centered horizontally on center.
+func addScaledText(canvas *Canvas, text string, center imgui.Vec2, scale float32, col color.RGBA) {
+	lineHeight := imgui.CalcTextSize("", false, 0).Y
+	w, _ := CalcTextSize(text)
+	canvas.AddTextVF(nil, lineHeight*scale, imgui.Vec2{X: center.X - w*scale/2, Y: center.Y}, col, text, 0, nil)
+}
+
+const (
+	gaugeStartAngle = 0.75 * math.Pi
+	gaugeSweep      = 1.5 * math.Pi
+)
+
+type GaugeWidget struct {
+	value  float64
+	min    float64
+	max    float64
+	size   float32
+	bands  []GaugeBand
+	label  string
+	format string
+}
+
+// Gauge shows value between min and max on a radial arc, size is its diameter.
+func Gauge(value, min, max float64, size float32) *GaugeWidget {
+	return GaugeV(value, min, max, size, nil, "", "%.0f")
+}
+
+// GaugeV works like Gauge with threshold bands along the arc, a label below the value, and the format of the value.
+func GaugeV(value, min, max float64, size float32, bands []GaugeBand, label, format string) *GaugeWidget {
+	return &GaugeWidget{
+		value:  value,
+		min:    min,
+		max:    max,
+		size:   size,
+		bands:  bands,
+		label:  label,
+		format: format,
+	}
+}
+
+func (g *GaugeWidget) Build() {
+	colors := currentDashboardColors()
+	lineHeight := imgui.CalcTextSize("", false, 0).Y
+	radius := g.size/2 - 2
+	thickness := radius * 0.18
+	height := g.size/2 + radius*float32(math.Sqrt2/2) + lineHeight + 2
+
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(imgui.Vec2{X: g.size, Y: height})
+	if radius <= thickness {
+		return
+	}
+
+	canvas := GetCanvas()
+	center := pos.Plus(imgui.Vec2{X: g.size / 2, Y: g.size / 2})
+	angle := func(v float64) float32 {
+		return float32(gaugeStartAngle + gaugeSweep*float64(clampFraction(v, g.min, g.max)))
+	}
+	arc := func(r, from, to, width float32, col color.RGBA) {
+		if to <= from {
+			return
+		}
+		segments := int(math.Max(4, float64((to-from)/gaugeSweep*48)))
+		canvas.PathArcToF(center, r, from, to, segments)
+		canvas.PathStroke(col, false, width)
+	}
+
+	arc(radius-thickness/2, gaugeStartAngle, gaugeStartAngle+gaugeSweep, thickness, colors.frame)
+	arc(radius-thickness/2, gaugeStartAngle, angle(g.value), thickness, colors.valueColor(g.value, g.bands))
+	for _, b := range g.bands {
+		arc(radius-thickness-4, angle(b.From), angle(b.To), 3, b.Color)
+	}
+
+	// Value in the center, scaled with the gauge, and the label below it.
+	scale := float32(math.Max(1, float64(radius/lineHeight/2.5)))
+	text := fmt.Sprintf(g.format, g.value)
+	addScaledText(canvas, text, center.Minus(imgui.Vec2{X: 0, Y: lineHeight * scale / 2}), scale, colors.text)
+	if g.label != "" {
+		addScaledText(canvas, g.label, center.Plus(imgui.Vec2{X: 0, Y: lineHeight * scale / 2}), 1, colors.textDisabled)
+	}
+
+	// Range at the ends of the arc.
+	endY := center.Y + radius*float32(math.Sqrt2/2) + 2
+	offset := radius * float32(math.Sqrt2/2)
+	addScaledText(canvas, fmt.Sprintf(g.format, g.min), imgui.Vec2{X: center.X - offset, Y: endY}, 1, colors.textDisabled)
+	addScaledText(canvas, fmt.Sprintf(g.format, g.max), imgui.Vec2{X: center.X + offset, Y: endY}, 1, colors.textDisabled)
+}
+
+type LinearMeterWidget struct {
+	value  float64
+	min    float64
+	max    float64
+	width  float32
+	height float32
+	bands  []GaugeBand
+	format string
+}
+
+// LinearMeter shows value between min and max as a filled bar. Width -1 fills the available width.
+func LinearMeter(value, min, max float64, width, height float32) *LinearMeterWidget {
+	return LinearMeterV(value, min, max, width, height, nil, "%.0f")
+}
+
+// LinearMeterV works like LinearMeter with threshold bands below the bar, and the format of the value.
+func LinearMeterV(value, min, max float64, width, height float32, bands []GaugeBand, format string) *LinearMeterWidget {
+	return &LinearMeterWidget{
+		value:  value,
+		min:    min,
+		max:    max,
+		width:  width,
+		height: height,
+		bands:  bands,
+		format: format,
+	}
+}
+
+func (m *LinearMeterWidget) Build() {
+	size := resolveSize(m.width, m.height)
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(size)
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+
+	colors := currentDashboardColors()
+	canvas := GetCanvas()
+
+	barHeight := size.Y
+	if len(m.bands) > 0 {
+		barHeight -= 5
+	}
+	barMax := pos.Plus(imgui.Vec2{X: size.X, Y: barHeight})
+	canvas.AddRectFilledF(pos, barMax, colors.frame, 3, CornerFlags_All)
+	fill := pos.X + size.X*clampFraction(m.value, m.min, m.max)
+	if fill > pos.X {
+		canvas.AddRectFilledF(pos, imgui.Vec2{X: fill, Y: barMax.Y}, colors.valueColor(m.value, m.bands), 3, CornerFlags_All)
+	}
+
+	for _, b := range m.bands {
+		x0 := pos.X + size.X*clampFraction(b.From, m.min, m.max)
+		x1 := pos.X + size.X*clampFraction(b.To, m.min, m.max)
+		canvas.AddRectFilledF(imgui.Vec2{X: x0, Y: barMax.Y + 2}, imgui.Vec2{X: x1, Y: pos.Y + size.Y}, b.Color, 0, CornerFlags_None)
+	}
+
+	text := fmt.Sprintf(m.format, m.value)
+	lineHeight := imgui.CalcTextSize("", false, 0).Y
+	if barHeight >= lineHeight {
+		addScaledText(canvas, text, imgui.Vec2{X: pos.X + size.X/2, Y: pos.Y + (barHeight-lineHeight)/2}, 1, colors.text)
+	}
+}
+
+type SparklineWidget struct {
+	values []float64
+	width  float32
+	height float32
+	color  color.RGBA
+	fill   bool
+}
+
+// Sparkline draws values as a small line without axes, with the last value marked. Width -1 fills the available width.
+func Sparkline(values []float64, width, height float32) *SparklineWidget {
+	return SparklineV(values, width, height, color.RGBA{}, false)
+}
+
+// SparklineV works like Sparkline, a zero color uses the plot lines color of the style, and fill shades the area below the line.
+func SparklineV(values []float64, width, height float32, color color.RGBA, fill bool) *SparklineWidget {
+	return &SparklineWidget{
+		values: values,
+		width:  width,
+		height: height,
+		color:  color,
+		fill:   fill,
+	}
+}
+
+func (s *SparklineWidget) Build() {
+	size := resolveSize(s.width, s.height)
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(size)
+	drawSparkline(GetCanvas(), s.values, pos, size, s.color, s.fill)
+}
+
+func drawSparkline(canvas *Canvas, values []float64, pos, size imgui.Vec2, col color.RGBA, fill bool) {
+	if len(values) < 2 || size.X <= 0 || size.Y <= 0 {
+		return
+	}
+	if col.A == 0 {
+		col = Vec4ToRGBA(imgui.CurrentStyle().GetColor(imgui.StyleColorPlotLines))
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		min, max = math.Min(min, v), math.Max(max, v)
+	}
+	if min == max {
+		min, max = min-1, max+1
+	}
+
+	// Leave room for the marker of the last value.
+	const margin = 2
+	points := make([]imgui.Vec2, len(values))
+	for i, v := range values {
+		points[i] = imgui.Vec2{
+			X: pos.X + margin + (size.X-2*margin)*float32(i)/float32(len(values)-1),
+			Y: pos.Y + margin + (size.Y-2*margin)*(1-float32((v-min)/(max-min))),
+		}
+	}
+
+	if fill {
+		shade := col
+		shade.A /= 4
+		bottom := pos.Y + size.Y
+		for i := 1; i < len(points); i++ {
+			a, b := points[i-1], points[i]
+			canvas.AddQuadFilledF(a, b, imgui.Vec2{X: b.X, Y: bottom}, imgui.Vec2{X: a.X, Y: bottom}, shade)
+		}
+	}
+	canvas.AddPolylineF(points, col, false, 1)
+	canvas.AddCircleFilledF(points[len(points)-1], margin, col, 8)
+}
+
+type KPIWidget struct {
+	label       string
+	value       string
+	width       float32
+	delta       float64
+	deltaFormat string
+	trend       []float64
+}
+
+// KPI shows a key figure on a card, with its label above it.
+func KPI(label, value string) *KPIWidget {
+	return KPIV(label, value, 160, 0, "", nil)
+}
+
+// KPIV works like KPI with the width of the card, the change of the figure formatted with deltaFormat,
+// shown green when it's positive and red when it's negative, and its trend as a sparkline.
+// An empty deltaFormat hides the change.
+func KPIV(label, value string, width float32, delta float64, deltaFormat string, trend []float64) *KPIWidget {
+	return &KPIWidget{
+		label:       label,
+		value:       value,
+		width:       width,
+		delta:       delta,
+		deltaFormat: deltaFormat,
+		trend:       trend,
+	}
+}
+
+var (
+	kpiPositiveColor = color.RGBA{76, 175, 80, 255}
+	kpiNegativeColor = color.RGBA{229, 57, 53, 255}
+)
+
+func (k *KPIWidget) Build() {
+	colors := currentDashboardColors()
+	lineHeight := imgui.CalcTextSize("", false, 0).Y
+	const padding = 8
+	const valueScale = 1.8
+
+	height := padding*2 + lineHeight + 4 + lineHeight*valueScale
+	if len(k.trend) > 1 {
+		height += lineHeight*1.5 + 4
+	}
+	size := resolveSize(k.width, height)
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(size)
+	if size.X <= 0 {
+		return
+	}
+
+	canvas := GetCanvas()
+	canvas.AddRectFilledF(pos, pos.Plus(size), colors.frame, 4, CornerFlags_All)
+
+	cursor := pos.Plus(imgui.Vec2{X: padding, Y: padding})
+	canvas.AddTextF(cursor, colors.textDisabled, k.label)
+	cursor.Y += lineHeight + 4
+
+	canvas.AddTextVF(nil, lineHeight*valueScale, cursor, colors.text, k.value, 0, nil)
+
+	if k.deltaFormat != "" {
+		col := kpiPositiveColor
+		if k.delta < 0 {
+			col = kpiNegativeColor
+		}
+		text := fmt.Sprintf(k.deltaFormat, k.delta)
+		w, _ := CalcTextSize(text)
+		textPos := imgui.Vec2{X: pos.X + size.X - padding - w, Y: cursor.Y + lineHeight*(valueScale-1)}
+		canvas.AddTextF(textPos, col, text)
+
+		// Arrow pointing up or down in front of the change.
+		arrow := lineHeight * 0.3
+		c := imgui.Vec2{X: textPos.X - arrow - 3, Y: textPos.Y + lineHeight/2}
+		if k.delta >= 0 {
+			canvas.AddTriangleFilledF(imgui.Vec2{X: c.X, Y: c.Y - arrow}, imgui.Vec2{X: c.X + arrow, Y: c.Y + arrow}, imgui.Vec2{X: c.X - arrow, Y: c.Y + arrow}, col)
+		} else {
+			canvas.AddTriangleFilledF(imgui.Vec2{X: c.X - arrow, Y: c.Y - arrow}, imgui.Vec2{X: c.X + arrow, Y: c.Y - arrow}, imgui.Vec2{X: c.X, Y: c.Y + arrow}, col)
+		}
+	}
+	cursor.Y += lineHeight*valueScale + 4
+
+	if len(k.trend) > 1 {
+		drawSparkline(canvas, k.trend, cursor, imgui.Vec2{X: size.X - 2*padding, Y: lineHeight * 1.5}, color.RGBA{}, true)
+	}
+}
@@ -0,0 +1,485 @@
+package giu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// DataTableSelectionMode defines how rows of a DataTable are selected by clicks.
+type DataTableSelectionMode int
+
+const (
+	// DataTableSelectNone disables selecting rows.
+	DataTableSelectNone DataTableSelectionMode = iota
+	// DataTableSelectSingle selects the clicked row.
+	DataTableSelectSingle
+	// DataTableSelectMulti selects the clicked row, ctrl toggles single rows and shift selects ranges.
+	DataTableSelectMulti
+)
+
+// dataTableMinColumnWidth is the smallest initial width of columns sharing the remaining width.
+const dataTableMinColumnWidth = 40
+
+// DataColumn defines a column of a DataTable. Cells are looked up by the index of their row in the data,
+// which is kept while sorting.
+type DataColumn struct {
+	Title string
+	// Width is the initial width in pixels, columns with 0 share the remaining width.
+	Width float32
+	// Text returns the text of a cell.
+	Text func(row int) string
+	// Widget returns the widget of a cell, it's used instead of Text if set.
+	Widget func(row int) Widget
+	// Less reports whether row a sorts before row b, columns without it aren't sortable.
+	Less func(a, b int) bool
+}
+
+// TextColumn creates a column of values sorted alphabetically.
+func TextColumn(title string, values []string) *DataColumn {
+	return &DataColumn{
+		Title: title,
+		Text: func(row int) string {
+			if row < len(values) {
+				return values[row]
+			}
+			return ""
+		},
+		Less: func(a, b int) bool {
+			if less, ok := missingValueLess(a, b, len(values)); ok {
+				return less
+			}
+			return values[a] < values[b]
+		},
+	}
+}
+
+// IntColumn creates a column of integers sorted numerically.
+func IntColumn(title string, values []int) *DataColumn {
+	return &DataColumn{
+		Title: title,
+		Text: func(row int) string {
+			if row < len(values) {
+				return strconv.Itoa(values[row])
+			}
+			return ""
+		},
+		Less: func(a, b int) bool {
+			if less, ok := missingValueLess(a, b, len(values)); ok {
+				return less
+			}
+			return values[a] < values[b]
+		},
+	}
+}
+
+// FloatColumn creates a column of numbers formatted with format and sorted numerically.
+func FloatColumn(title string, values []float64, format string) *DataColumn {
+	return &DataColumn{
+		Title: title,
+		Text: func(row int) string {
+			if row < len(values) {
+				return fmt.Sprintf(format, values[row])
+			}
+			return ""
+		},
+		Less: func(a, b int) bool {
+			if less, ok := missingValueLess(a, b, len(values)); ok {
+				return less
+			}
+			return values[a] < values[b]
+		},
+	}
+}
+
+// missingValueLess orders rows a and b if either of them is past the n values of a column,
+// rows without a value sort after the others.
+func missingValueLess(a, b, n int) (less, ok bool) {
+	if a < n && b < n {
+		return false, false
+	}
+	return a < n, true
+}
+
+// FuncColumn creates a column of texts returned by text, sorted by less which may be nil.
+func FuncColumn(title string, text func(row int) string, less func(a, b int) bool) *DataColumn {
+	return &DataColumn{Title: title, Text: text, Less: less}
+}
+
+// WidgetColumn creates a column of widgets returned by widget, e.g. buttons or checkboxes.
+// It isn't sortable unless Less is set.
+func WidgetColumn(title string, widget func(row int) Widget) *DataColumn {
+	return &DataColumn{Title: title, Widget: widget}
+}
+
+// DataTableState keeps the column widths, the sorting and the selection of a DataTable between frames.
+type DataTableState struct {
+	widths []float32
+	hidden []bool
+	layout string // visible columns whose widths were applied to the header
+
+	sorted     bool
+	sortColumn int
+	descending bool
+	order      []int // rows in display order
+	orderValid bool
+
+	selected  map[int]bool
+	anchorRow int // last clicked row, where shift ranges start
+	rows      int
+}
+
+// SortColumn returns the column the rows are sorted by, or -1 if they aren't sorted.
+func (s *DataTableState) SortColumn() (column int, descending bool) {
+	if !s.sorted {
+		return -1, false
+	}
+	return s.sortColumn, s.descending
+}
+
+// SetSort sorts the rows by column, a column of -1 restores the order of the data.
+func (s *DataTableState) SetSort(column int, descending bool) {
+	s.sorted = column >= 0
+	s.sortColumn, s.descending = column, descending
+	s.orderValid = false
+}
+
+// Refresh sorts the rows again in the next frame, call it after the data changed.
+// Changes of the row count are noticed without it.
+func (s *DataTableState) Refresh() {
+	s.orderValid = false
+}
+
+// IsColumnHidden reports whether column is hidden.
+func (s *DataTableState) IsColumnHidden(column int) bool {
+	return column < len(s.hidden) && s.hidden[column]
+}
+
+// SetColumnHidden hides or shows column. The columns can also be toggled by right-clicking the header.
+func (s *DataTableState) SetColumnHidden(column int, hidden bool) {
+	for len(s.hidden) <= column {
+		s.hidden = append(s.hidden, false)
+	}
+	s.hidden[column] = hidden
+}
+
+// IsSelected reports whether row is selected.
+func (s *DataTableState) IsSelected(row int) bool {
+	return s.selected[row]
+}
+
+// Selection returns the selected rows in ascending order.
+func (s *DataTableState) Selection() []int {
+	rows := make([]int, 0, len(s.selected))
+	for row := range s.selected {
+		if row < s.rows {
+			rows = append(rows, row)
+		}
+	}
+	sort.Ints(rows)
+	return rows
+}
+
+// SetSelection selects rows, replacing the selection.
+func (s *DataTableState) SetSelection(rows ...int) {
+	s.selected = make(map[int]bool, len(rows))
+	for _, row := range rows {
+		s.selected[row] = true
+	}
+}
+
+// ClearSelection deselects all rows.
+func (s *DataTableState) ClearSelection() {
+	s.selected = nil
+}
+
+// sort updates the display order of rows if it's outdated.
+func (s *DataTableState) sort(columns []*DataColumn, rows int) {
+	if s.orderValid && len(s.order) == rows {
+		return
+	}
+	if cap(s.order) < rows {
+		s.order = make([]int, rows)
+	}
+	s.order = s.order[:rows]
+	for i := range s.order {
+		s.order[i] = i
+	}
+
+	if s.sorted && s.sortColumn < len(columns) && columns[s.sortColumn].Less != nil {
+		less := columns[s.sortColumn].Less
+		sort.SliceStable(s.order, func(i, j int) bool {
+			if s.descending {
+				return less(s.order[j], s.order[i])
+			}
+			return less(s.order[i], s.order[j])
+		})
+	}
+	s.orderValid = true
+}
+
+// click updates the selection for a click on the row at display position pos.
+func (s *DataTableState) click(pos int, mode DataTableSelectionMode) {
+	row := s.order[pos]
+	io := Context.IO()
+	if mode == DataTableSelectSingle || s.selected == nil {
+		s.SetSelection(row)
+		s.anchorRow = row
+		return
+	}
+
+	switch {
+	case io.KeyShiftPressed():
+		if !io.KeyCtrlPressed() {
+			s.selected = make(map[int]bool)
+		}
+		from := pos
+		for i, r := range s.order {
+			if r == s.anchorRow {
+				from = i
+				break
+			}
+		}
+		if from > pos {
+			from, pos = pos, from
+		}
+		for i := from; i <= pos; i++ {
+			s.selected[s.order[i]] = true
+		}
+	case io.KeyCtrlPressed():
+		if s.selected[row] {
+			delete(s.selected, row)
+		} else {
+			s.selected[row] = true
+		}
+		s.anchorRow = row
+	default:
+		s.SetSelection(row)
+		s.anchorRow = row
+	}
+}
+
+type DataTableWidget struct {
+	id                string
+	state             *DataTableState
+	rows              int
+	width             float32
+	height            float32
+	selection         DataTableSelectionMode
+	onSelectionChange func(rows []int)
+	columns           []*DataColumn
+}
+
+// DataTable shows rows of columns below a header that stays in place while scrolling.
+// Clicking a header sorts by its column, dragging its borders resizes the columns and right-clicking it
+// hides or shows columns. Only visible rows are built, so large data sets stay fast.
+// Width or height -1 fill the available space.
+func DataTable(id string, state *DataTableState, rows int, width, height float32, columns ...*DataColumn) *DataTableWidget {
+	return DataTableV(id, state, rows, width, height, DataTableSelectSingle, nil, columns...)
+}
+
+// DataTableV works like DataTable, selection sets how rows are selected
+// and onSelectionChange is invoked with the selected rows when a click changed them.
+func DataTableV(id string, state *DataTableState, rows int, width, height float32, selection DataTableSelectionMode, onSelectionChange func(rows []int), columns ...*DataColumn) *DataTableWidget {
+	return &DataTableWidget{
+		id:                id,
+		state:             state,
+		rows:              rows,
+		width:             width,
+		height:            height,
+		selection:         selection,
+		onSelectionChange: onSelectionChange,
+		columns:           columns,
+	}
+}
+
+func (t *DataTableWidget) Build() {
+	s := t.state
+	if s == nil || len(t.columns) == 0 {
+		return
+	}
+	size := resolveSize(t.width, t.height)
+	for len(s.widths) < len(t.columns) {
+		s.widths = append(s.widths, t.columns[len(s.widths)].Width)
+	}
+	for len(s.hidden) < len(t.columns) {
+		s.hidden = append(s.hidden, false)
+	}
+	s.rows = t.rows
+	s.sort(t.columns, t.rows)
+
+	var visible []int
+	for i := range t.columns {
+		if !s.hidden[i] {
+			visible = append(visible, i)
+		}
+	}
+	if len(visible) == 0 {
+		for i := range t.columns {
+			s.hidden[i] = false
+			visible = append(visible, i)
+		}
+	}
+	layout := strings.Trim(fmt.Sprint(visible), "[]")
+
+	style := imgui.CurrentStyle()
+	headerHeight := imgui.TextLineHeightWithSpacing()
+	// The body always shows its scrollbar, the header leaves room for it so the columns line up.
+	headerWidth := size.X - style.ScrollbarSize()
+
+	imgui.PushID(t.id)
+	pos := imgui.CursorScreenPos()
+
+	imgui.BeginChildV("header", imgui.Vec2{X: headerWidth, Y: headerHeight}, false, imgui.WindowFlagsNoScrollbar|imgui.WindowFlagsNoScrollWithMouse)
+	canvas := GetCanvas()
+	canvas.AddRectFilledF(pos, pos.Plus(imgui.Vec2{X: headerWidth, Y: headerHeight}), Vec4ToRGBA(style.GetColor(imgui.StyleColorHeader)), 0, CornerFlags_None)
+
+	imgui.ColumnsV(len(visible), "header "+layout, true)
+	if s.layout != layout {
+		t.initWidths(s, visible, headerWidth)
+		s.layout = layout
+	} else {
+		// Take over widths resized by dragging the borders.
+		for j, c := range visible {
+			s.widths[c] = float32(imgui.ColumnWidthV(j))
+		}
+	}
+
+	textColor := Vec4ToRGBA(style.GetColor(imgui.StyleColorText))
+	for j, c := range visible {
+		column := t.columns[c]
+		if imgui.SelectableV(column.Title+"##"+strconv.Itoa(c), false, 0, imgui.Vec2{}) && column.Less != nil {
+			s.SetSort(c, s.sorted && s.sortColumn == c && !s.descending)
+		}
+		if imgui.IsItemClickedV(1) {
+			imgui.OpenPopup("columns")
+		}
+		if s.sorted && s.sortColumn == c {
+			// Arrow at the right of the header, pointing up when ascending.
+			min, max := imgui.ItemRectMin(), imgui.ItemRectMax()
+			h := (max.Y - min.Y) / 3
+			center := imgui.Vec2{X: min.X + float32(imgui.ColumnWidthV(j)) - h*2, Y: (min.Y + max.Y) / 2}
+			tip, base := -h/2, h/2
+			if s.descending {
+				tip, base = base, tip
+			}
+			canvas.AddTriangleFilledF(
+				imgui.Vec2{X: center.X, Y: center.Y + tip},
+				imgui.Vec2{X: center.X - h/2, Y: center.Y + base},
+				imgui.Vec2{X: center.X + h/2, Y: center.Y + base},
+				textColor,
+			)
+		}
+		imgui.NextColumn()
+	}
+	imgui.Columns()
+
+	if imgui.BeginPopup("columns") {
+		for i, column := range t.columns {
+			// The last visible column can't be hidden.
+			if imgui.MenuItemV(column.Title, "", !s.hidden[i], s.hidden[i] || len(visible) > 1) {
+				s.hidden[i] = !s.hidden[i]
+			}
+		}
+		imgui.EndPopup()
+	}
+	imgui.EndChild()
+
+	imgui.BeginChildV("body", imgui.Vec2{X: size.X, Y: size.Y - headerHeight}, false, imgui.WindowFlagsAlwaysVerticalScrollbar)
+	imgui.ColumnsV(len(visible), "body "+layout, false)
+	for j, c := range visible[:len(visible)-1] {
+		imgui.SetColumnWidth(j, s.widths[c])
+	}
+
+	changed := false
+	var clipper imgui.ListClipper
+	clipper.Begin(t.rows)
+	for clipper.Step() {
+		for i := clipper.DisplayStart; i < clipper.DisplayEnd; i++ {
+			row := s.order[i]
+			imgui.PushID(strconv.Itoa(row))
+			for j, c := range visible {
+				if j == 0 && t.selection != DataTableSelectNone {
+					if t.buildSelectableCell(t.columns[c], row) {
+						s.click(i, t.selection)
+						changed = true
+					}
+				} else {
+					t.buildCell(t.columns[c], row)
+				}
+				imgui.NextColumn()
+			}
+			imgui.PopID()
+		}
+	}
+	imgui.Columns()
+
+	// The body has no column borders of its own, so they can only be dragged in the header.
+	top := pos.Y + headerHeight
+	bottom := minf(pos.Y+size.Y, imgui.CursorScreenPos().Y)
+	borderColor := Vec4ToRGBA(style.GetColor(imgui.StyleColorBorder))
+	x := pos.X
+	for _, c := range visible[:len(visible)-1] {
+		x += s.widths[c]
+		GetCanvas().AddLineF(imgui.Vec2{X: x, Y: top}, imgui.Vec2{X: x, Y: bottom}, borderColor, 1)
+	}
+	imgui.EndChild()
+	imgui.PopID()
+
+	if changed && t.onSelectionChange != nil {
+		t.onSelectionChange(s.Selection())
+	}
+}
+
+// initWidths applies the widths of visible columns to the header, columns without a width share the rest.
+func (t *DataTableWidget) initWidths(s *DataTableState, visible []int, width float32) {
+	rest, shared := width, 0
+	for _, c := range visible {
+		if s.widths[c] > 0 {
+			rest -= s.widths[c]
+		} else {
+			shared++
+		}
+	}
+	for _, c := range visible {
+		if s.widths[c] <= 0 {
+			s.widths[c] = maxf(dataTableMinColumnWidth, rest/float32(shared))
+		}
+	}
+	for j, c := range visible[:len(visible)-1] {
+		imgui.SetColumnWidth(j, s.widths[c])
+	}
+}
+
+func (t *DataTableWidget) buildCell(column *DataColumn, row int) {
+	switch {
+	case column.Widget != nil:
+		if w := column.Widget(row); w != nil {
+			w.Build()
+		}
+	case column.Text != nil:
+		imgui.Text(column.Text(row))
+	}
+}
+
+// buildSelectableCell builds the cell of the first column with a selectable spanning the row, and reports clicks on it.
+func (t *DataTableWidget) buildSelectableCell(column *DataColumn, row int) bool {
+	flags := imgui.SelectableFlagsSpanAllColumns
+	if column.Widget == nil {
+		text := ""
+		if column.Text != nil {
+			text = column.Text(row)
+		}
+		return imgui.SelectableV(text+"##row", t.state.selected[row], flags, imgui.Vec2{})
+	}
+
+	// Widgets are put next to a narrow selectable and can be used on top of it.
+	clicked := imgui.SelectableV("##row", t.state.selected[row], flags, imgui.Vec2{X: 1})
+	imgui.SetItemAllowOverlap()
+	imgui.SameLine()
+	t.buildCell(column, row)
+	return clicked
+}
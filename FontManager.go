@@ -0,0 +1,409 @@
+package giu
+
+import (
+	"sync"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// FontRanges selects which glyphs of a fallback font are merged into its base font.
+type FontRanges int
+
+const (
+	// FontRangesDefault covers Basic Latin and Extended Latin.
+	FontRangesDefault FontRanges = iota
+	// FontRangesCyrillic covers Default + about 400 Cyrillic characters.
+	FontRangesCyrillic
+	// FontRangesChineseSimplifiedCommon covers Default + Half-Width + Japanese Hiragana/Katakana + 2500 common CJK Unified Ideographs.
+	FontRangesChineseSimplifiedCommon
+	// FontRangesChineseFull covers Default + Half-Width + Japanese Hiragana/Katakana + about 21000 CJK Unified Ideographs.
+	FontRangesChineseFull
+	// FontRangesJapanese covers Default + Hiragana, Katakana, Half-Width and a selection of 1946 Ideographs.
+	FontRangesJapanese
+	// FontRangesKorean covers Default + Korean characters.
+	FontRangesKorean
+	// FontRangesThai covers Default + Thai characters.
+	FontRangesThai
+)
+
+func (r FontRanges) glyphRanges(atlas imgui.FontAtlas) imgui.GlyphRanges {
+	switch r {
+	case FontRangesCyrillic:
+		return atlas.GlyphRangesCyrillic()
+	case FontRangesChineseSimplifiedCommon:
+		return atlas.GlyphRangesChineseSimplifiedCommon()
+	case FontRangesChineseFull:
+		return atlas.GlyphRangesChineseFull()
+	case FontRangesJapanese:
+		return atlas.GlyphRangesJapanese()
+	case FontRangesKorean:
+		return atlas.GlyphRangesKorean()
+	case FontRangesThai:
+		return atlas.GlyphRangesThai()
+	default:
+		return atlas.GlyphRangesDefault()
+	}
+}
+
+type fontFallback struct {
+	ttf        []byte
+	ranges     FontRanges
+	charRanges [][2]rune
+}
+
+// FontInfo is a named font registered in Fonts.
+type FontInfo struct {
+	name      string
+	ttf       []byte
+	size      float32
+	fallbacks []fontFallback
+	font      imgui.Font
+}
+
+// Name returns the name the font was registered with.
+func (f *FontInfo) Name() string {
+	return f.name
+}
+
+// Size returns the pixel size of the font.
+func (f *FontInfo) Size() float32 {
+	return f.size
+}
+
+// Fallback merges the glyphs of ttf into this font, for glyphs the font itself lacks.
+// If text has been registered with Fonts.RegisterString only the glyphs of that text are baked,
+// otherwise the whole range is.
+func (f *FontInfo) Fallback(ttf []byte, ranges FontRanges) *FontInfo {
+	Fonts.mu.Lock()
+	defer Fonts.mu.Unlock()
+
+	f.fallbacks = append(f.fallbacks, fontFallback{ttf: ttf, ranges: ranges})
+	Fonts.invalidate()
+	return f
+}
+
+// FallbackChars merges the given inclusive character ranges of ttf into this font.
+// It is meant for icon fonts, e.g. FallbackChars(iconTTF, [2]rune{0xf000, 0xf8ff}).
+func (f *FontInfo) FallbackChars(ttf []byte, charRanges ...[2]rune) *FontInfo {
+	Fonts.mu.Lock()
+	defer Fonts.mu.Unlock()
+
+	f.fallbacks = append(f.fallbacks, fontFallback{ttf: ttf, charRanges: charRanges})
+	Fonts.invalidate()
+	return f
+}
+
+type fontManager struct {
+	mu            sync.Mutex
+	fonts         []*FontInfo
+	defaultFont   string
+	usedText      map[rune]bool
+	builder       imgui.FontGlyphRangesBuilder
+	ranges        []imgui.GlyphRanges
+	freeTypeFlags int
+	useFreeType   bool
+	scale         float32
+	drawScale     float32
+	foreign       bool
+	loaded        bool
+	dirty         bool
+}
+
+// Fonts manages named fonts and their fallback chains.
+// Fonts registered before the master window is created are loaded with it. Changes made
+// while the application runs are queued and the font atlas is rebuilt before the next frame.
+//
+// Note: a rebuild clears the whole font atlas. If fonts were added directly to the atlas
+// (e.g. in the loadFontFunc of NewMasterWindow), changes aren't applied so those fonts are kept.
+var Fonts = &fontManager{
+	usedText:  make(map[rune]bool),
+	scale:     1,
+	drawScale: 1,
+}
+
+// Register adds a font with given name, TTF/OTF data and pixel size.
+// Registering a name again replaces the data of the previous font.
+func (m *fontManager) Register(name string, ttf []byte, size float32) *FontInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	defer m.invalidate()
+
+	if info := m.find(name); info != nil {
+		info.ttf = ttf
+		info.size = size
+		info.fallbacks = nil
+		return info
+	}
+
+	info := &FontInfo{
+		name: name,
+		ttf:  ttf,
+		size: size,
+	}
+	m.fonts = append(m.fonts, info)
+	return info
+}
+
+// Remove removes the named font, Get returns nil for it afterwards.
+func (m *fontManager) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, f := range m.fonts {
+		if f.name == name {
+			m.fonts = append(m.fonts[:i], m.fonts[i+1:]...)
+			m.invalidate()
+			return
+		}
+	}
+}
+
+// SetSize changes the pixel size of the named font.
+func (m *fontManager) SetSize(name string, size float32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if info := m.find(name); info != nil && info.size != size {
+		info.size = size
+		m.invalidate()
+	}
+}
+
+// SetDefault makes the named font the one used by all widgets unless another font is pushed.
+func (m *fontManager) SetDefault(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.defaultFont != name {
+		m.defaultFont = name
+		m.invalidate()
+	}
+}
+
+// SetFreeTypeFlags makes the atlas being built with FreeType using given FreeTypeRasterizerFlags.
+// The default rasterizer is used if FreeType is not available in this build.
+func (m *fontManager) SetFreeTypeFlags(flags int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.useFreeType = true
+	m.freeTypeFlags = flags
+	m.invalidate()
+}
+
+// RegisterString records text the application displays, so glyph ranges can be built from it
+// instead of baking whole (and for CJK, huge) ranges.
+// Registering text with new characters after the fonts were loaded rebuilds the atlas.
+func (m *fontManager) RegisterString(texts ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, text := range texts {
+		for _, r := range text {
+			if !m.usedText[r] {
+				m.usedText[r] = true
+				m.invalidate()
+			}
+		}
+	}
+}
+
+// setScale makes fonts being rasterized at their size multiplied by scale, and drawn scaled by drawScale.
+// drawScale is less than 1 where the framebuffer is larger than the window, so the fonts keep their size.
+func (m *fontManager) setScale(scale, drawScale float32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.scale != scale || m.drawScale != drawScale {
+		m.scale = scale
+		m.drawScale = drawScale
+		m.invalidate()
+	}
+}
+
+// invalidate marks the atlas for rebuilding once the fonts were loaded.
+// The atlas isn't rebuilt if it has fonts the manager didn't add, clearing it would drop them.
+func (m *fontManager) invalidate() {
+	if !m.loaded || m.dirty || m.foreign {
+		return
+	}
+
+	m.dirty = true
+	if Context.platform != nil {
+		Update()
+	}
+}
+
+// Get returns the named font, for LabelV and PushFont. Returns nil if the font is unknown.
+// The result is only valid in the current frame on the main thread: changes of the fonts rebuild the atlas
+// between frames, so call Get while building the UI every frame instead of keeping the font.
+func (m *fontManager) Get(name string) *imgui.Font {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if info := m.find(name); info != nil {
+		// A copy, the font of info is replaced by rebuild.
+		font := info.font
+		return &font
+	}
+	return nil
+}
+
+// Push pushes the named font, or the default font if the name is unknown.
+// Always pair it with PopFont.
+func (m *fontManager) Push(name string) {
+	font := imgui.DefaultFont
+	if f := m.Get(name); f != nil {
+		font = *f
+	}
+	imgui.PushFont(font)
+}
+
+func (m *fontManager) find(name string) *FontInfo {
+	for _, f := range m.fonts {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// load adds registered fonts to the atlas of the new master window.
+func (m *fontManager) load(io imgui.IO) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.loaded = true
+	m.foreign = io.Fonts().FontCount() > 0
+	if len(m.fonts) == 0 && m.scale == 1 && m.drawScale == 1 {
+		return
+	}
+
+	m.addToAtlas(io)
+	// Build with FreeType here, the renderer would otherwise build the atlas without the flags
+	// and with another default font.
+	if m.usesFreeType() {
+		m.build(io.Fonts())
+	}
+}
+
+// rebuild rebuilds the atlas if fonts changed and uploads it with renderer.
+// It must be called on the main thread between frames.
+func (m *fontManager) rebuild(io imgui.IO, renderer imgui.Renderer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.dirty {
+		return
+	}
+	m.dirty = false
+
+	atlas := io.Fonts()
+
+	// Font references become invalid with the atlas.
+	io.SetFontDefault(imgui.DefaultFont)
+
+	atlas.Clear()
+	for _, r := range m.ranges {
+		r.Free()
+	}
+	m.ranges = nil
+
+	m.addToAtlas(io)
+	m.build(atlas)
+
+	renderer.RefreshFontTexture()
+}
+
+func (m *fontManager) usesFreeType() bool {
+	return m.useFreeType || imgui.EnableFreeType
+}
+
+func (m *fontManager) build(atlas imgui.FontAtlas) {
+	if m.usesFreeType() {
+		if err := atlas.BuildWithFreeTypeV(m.freeTypeFlags); err == nil {
+			return
+		}
+	}
+	atlas.Build()
+}
+
+// addToAtlas adds all registered fonts and their fallbacks to atlas.
+func (m *fontManager) addToAtlas(io imgui.IO) {
+	atlas := io.Fonts()
+	if m.builder == 0 {
+		m.builder = imgui.NewFontGlyphRangesBuilder()
+	}
+
+	defaultInfo := m.find(m.defaultFont)
+	if defaultInfo == nil && !m.foreign {
+		// Keep imgui's built-in font as the default one, the first font of the atlas.
+		var font imgui.Font
+		if m.scale == 1 {
+			font = atlas.AddFontDefault()
+		} else {
+			config := imgui.NewFontConfig()
+			config.SetSize(13 * m.scale)
+			config.SetOversampleH(1)
+			config.SetOversampleV(1)
+			config.SetPixelSnapH(true)
+			font = atlas.AddFontDefaultV(config)
+			config.Delete()
+		}
+		font.SetScale(m.drawScale)
+	}
+
+	for _, info := range m.fonts {
+		config := imgui.NewFontConfig()
+		info.font = atlas.AddFontFromMemoryTTFV(info.ttf, info.size*m.scale, config, m.buildRanges(atlas.GlyphRangesDefault(), nil))
+		info.font.SetScale(m.drawScale)
+		config.Delete()
+
+		for _, fb := range info.fallbacks {
+			var ranges imgui.GlyphRanges
+			if len(fb.charRanges) > 0 {
+				ranges = m.buildRanges(imgui.EmptyGlyphRanges, fb.charRanges)
+			} else if len(m.usedText) > 0 {
+				ranges = m.buildRanges(imgui.EmptyGlyphRanges, nil)
+			} else {
+				ranges = fb.ranges.glyphRanges(atlas)
+			}
+
+			config := imgui.NewFontConfig()
+			config.SetMergeMode(true)
+			atlas.AddFontFromMemoryTTFV(fb.ttf, info.size*m.scale, config, ranges)
+			config.Delete()
+		}
+	}
+
+	if defaultInfo != nil {
+		io.SetFontDefault(defaultInfo.font)
+	}
+}
+
+// buildRanges combines base ranges, explicit character ranges and the registered text.
+func (m *fontManager) buildRanges(base imgui.GlyphRanges, charRanges [][2]rune) imgui.GlyphRanges {
+	m.builder.Clear()
+
+	if base != imgui.EmptyGlyphRanges {
+		m.builder.AddRanges(base)
+	}
+
+	for _, cr := range charRanges {
+		for c := cr[0]; c <= cr[1]; c++ {
+			m.builder.AddChar(c)
+		}
+	}
+
+	if len(charRanges) == 0 {
+		for r := range m.usedText {
+			m.builder.AddChar(r)
+		}
+	}
+
+	ranges := imgui.NewGlyphRanges()
+	m.builder.BuildRanges(ranges)
+	m.ranges = append(m.ranges, ranges)
+	return ranges.Data()
+}
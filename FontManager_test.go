@@ -0,0 +1,42 @@
+package giu
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFontManagerGet(t *testing.T) {
+	m := &fontManager{usedText: make(map[rune]bool), scale: 1, drawScale: 1}
+	assert.Nil(t, m.Get("mono"))
+
+	m.Register("mono", nil, 13)
+	font := m.Get("mono")
+	if assert.NotNil(t, font) {
+		*font = 1
+		assert.NotEqual(t, font, m.Get("mono"), "font expected to be a copy")
+		assert.NotEqual(t, *font, *m.Get("mono"))
+	}
+
+	m.Remove("mono")
+	assert.Nil(t, m.Get("mono"))
+}
+
+func TestFontManagerGetConcurrently(t *testing.T) {
+	m := &fontManager{usedText: make(map[rune]bool), scale: 1, drawScale: 1}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			m.Register("mono", nil, float32(i))
+			m.setScale(float32(i%3+1), 1)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		m.Get("mono")
+	}
+	wg.Wait()
+}
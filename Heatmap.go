@@ -0,0 +1,309 @@
+package giu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// heatmapCellLimit is the count of cells up to which cells are drawn as rectangles instead of a texture.
+const heatmapCellLimit = 64 * 64
+
+// HeatmapState keeps the texture of a large Heatmap and the hovered cell between frames.
+type HeatmapState struct {
+	texture  *Texture
+	pending  bool
+	rgba     *image.RGBA
+	values   []float64 // drawn into the texture
+	min, max float64
+	colormap *Colormap
+
+	hovered  bool
+	row, col int
+}
+
+// HoveredCell returns the cell under the mouse cursor, ok is false if the heatmap isn't hovered.
+func (s *HeatmapState) HoveredCell() (row, col int, ok bool) {
+	return s.row, s.col, s.hovered
+}
+
+// Release releases the texture of the heatmap, e.g. when it's not shown anymore.
+func (s *HeatmapState) Release() {
+	if s.texture != nil {
+		s.texture.Release()
+		s.texture = nil
+	}
+	s.values = nil
+}
+
+// updateTexture draws values into the texture if they changed since the last frame.
+func (s *HeatmapState) updateTexture(values []float64, rows, cols int, min, max float64, colormap *Colormap) {
+	if s.rgba != nil && s.rgba.Bounds() == image.Rect(0, 0, cols, rows) && s.min == min && s.max == max &&
+		s.colormap == colormap && equalFloats(s.values, values) {
+		return
+	}
+
+	if s.rgba == nil || s.rgba.Bounds() != image.Rect(0, 0, cols, rows) {
+		s.rgba = image.NewRGBA(image.Rect(0, 0, cols, rows))
+		if s.texture != nil {
+			s.texture.Release()
+			s.texture = nil
+		}
+	}
+	s.values = append(s.values[:0], values...)
+	s.min, s.max, s.colormap = min, max, colormap
+
+	for i, v := range values {
+		c := heatmapColor(v, min, max, colormap)
+		pix := s.rgba.Pix[i*4 : i*4+4]
+		pix[0], pix[1], pix[2], pix[3] = c.R, c.G, c.B, c.A
+	}
+
+	switch {
+	case s.texture != nil:
+		_ = s.texture.Update(s.rgba)
+	case !s.pending:
+		s.pending = true
+		rgba := image.NewRGBA(s.rgba.Rect)
+		copy(rgba.Pix, s.rgba.Pix)
+		options := imgui.TextureOptions{MinFilter: imgui.TextureFilterNearest, MagFilter: imgui.TextureFilterNearest}
+		EnqueueNewTextureFromRgbaV(rgba, options, func(texture *Texture, err error) {
+			s.pending = false
+			if err != nil {
+				return
+			}
+			if texture.width != s.rgba.Rect.Dx() || texture.height != s.rgba.Rect.Dy() {
+				// The matrix was resized meanwhile.
+				texture.Release()
+				return
+			}
+			s.texture = texture
+			_ = texture.Update(s.rgba)
+		})
+	}
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !(math.IsNaN(a[i]) && math.IsNaN(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func heatmapColor(v, min, max float64, colormap *Colormap) color.RGBA {
+	if math.IsNaN(v) {
+		return color.RGBA{}
+	}
+	if max == min {
+		return colormap.At(0.5)
+	}
+	return colormap.At((v - min) / (max - min))
+}
+
+type HeatmapWidget struct {
+	id           string
+	state        *HeatmapState
+	values       []float64
+	rows         int
+	cols         int
+	width        float32
+	height       float32
+	min          float64
+	max          float64
+	colormap     *Colormap
+	showColorBar bool
+	format       string
+}
+
+// Heatmap shows values, a matrix of rows times cols stored row by row from the top, as colored cells
+// of the viridis colormap scaled to their range, with a color bar. Width or height -1 fill the available space.
+// The value of the cell under the mouse cursor is shown in a tooltip. NaN values are left transparent.
+// Large matrices are drawn through a texture kept in state.
+func Heatmap(id string, state *HeatmapState, values []float64, rows, cols int, width, height float32) *HeatmapWidget {
+	return HeatmapV(id, state, values, rows, cols, width, height, 0, 0, ColormapViridis, true, "%.3g")
+}
+
+// HeatmapV works like Heatmap, values are mapped from min to max onto colormap, or their range if min equals max.
+// format formats the values of the readout, and of small cells that have room for them.
+func HeatmapV(id string, state *HeatmapState, values []float64, rows, cols int, width, height float32, min, max float64, colormap *Colormap, showColorBar bool, format string) *HeatmapWidget {
+	return &HeatmapWidget{
+		id:           id,
+		state:        state,
+		values:       values,
+		rows:         rows,
+		cols:         cols,
+		width:        width,
+		height:       height,
+		min:          min,
+		max:          max,
+		colormap:     colormap,
+		showColorBar: showColorBar,
+		format:       format,
+	}
+}
+
+func (h *HeatmapWidget) Build() {
+	size := imgui.Vec2{X: h.width, Y: h.height}
+	avail := imgui.ContentRegionAvail()
+	if size.X == -1 {
+		size.X = avail.X
+	}
+	if size.Y == -1 {
+		size.Y = avail.Y
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButton(h.id, size)
+
+	if h.rows <= 0 || h.cols <= 0 || len(h.values) < h.rows*h.cols || h.state == nil {
+		return
+	}
+	values := h.values[:h.rows*h.cols]
+	colormap := h.colormap
+	if colormap == nil {
+		colormap = ColormapViridis
+	}
+
+	min, max := h.min, h.max
+	if min == max {
+		min, max = math.Inf(1), math.Inf(-1)
+		for _, v := range values {
+			if !math.IsNaN(v) {
+				min, max = math.Min(min, v), math.Max(max, v)
+			}
+		}
+		if math.IsInf(min, 1) {
+			min, max = 0, 1
+		}
+	}
+
+	style := imgui.CurrentStyle()
+	textColor := Vec4ToRGBA(style.GetColor(imgui.StyleColorText))
+	lineHeight := imgui.CalcTextSize("", false, 0).Y
+
+	// The color bar takes the right side, with labels of the range.
+	mapSize := size
+	minLabel, maxLabel := fmt.Sprintf(h.format, min), fmt.Sprintf(h.format, max)
+	barWidth := float32(16)
+	if h.showColorBar {
+		w1, _ := CalcTextSize(minLabel)
+		w2, _ := CalcTextSize(maxLabel)
+		mapSize.X -= barWidth + maxf(w1, w2) + 16
+	}
+	if mapSize.X <= 0 {
+		return
+	}
+	mapMax := pos.Plus(mapSize)
+	cell := imgui.Vec2{X: mapSize.X / float32(h.cols), Y: mapSize.Y / float32(h.rows)}
+
+	canvas := GetCanvas()
+	if len(values) <= heatmapCellLimit {
+		h.state.Release()
+		showValues := cell.Y >= lineHeight
+		for r := 0; r < h.rows; r++ {
+			for c := 0; c < h.cols; c++ {
+				v := values[r*h.cols+c]
+				cellMin := pos.Plus(imgui.Vec2{X: float32(c) * cell.X, Y: float32(r) * cell.Y})
+				col := heatmapColor(v, min, max, colormap)
+				canvas.AddRectFilledF(cellMin, cellMin.Plus(cell), col, 0, CornerFlags_None)
+
+				if showValues && !math.IsNaN(v) {
+					text := fmt.Sprintf(h.format, v)
+					if w, _ := CalcTextSize(text); w <= cell.X-4 {
+						canvas.AddTextF(cellMin.Plus(imgui.Vec2{X: (cell.X - w) / 2, Y: (cell.Y - lineHeight) / 2}), contrastColor(col), text)
+					}
+				}
+			}
+		}
+	} else {
+		h.state.updateTexture(values, h.rows, h.cols, min, max, colormap)
+		canvas.AddImageF(h.state.texture, pos, mapMax, imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 1}, color.RGBA{255, 255, 255, 255})
+	}
+
+	if h.showColorBar {
+		barMin := imgui.Vec2{X: mapMax.X + 8, Y: pos.Y}
+		barMax := imgui.Vec2{X: barMin.X + barWidth, Y: mapMax.Y}
+		stops := colormap.stops
+		for i := 0; i+1 < len(stops); i++ {
+			// Top is the maximum.
+			y0 := barMax.Y - (barMax.Y-barMin.Y)*float32(i+1)/float32(len(stops)-1)
+			y1 := barMax.Y - (barMax.Y-barMin.Y)*float32(i)/float32(len(stops)-1)
+			canvas.AddRectFilledMultiColorF(imgui.Vec2{X: barMin.X, Y: y0}, imgui.Vec2{X: barMax.X, Y: y1}, stops[i+1], stops[i+1], stops[i], stops[i])
+		}
+		if len(stops) == 1 {
+			canvas.AddRectFilledF(barMin, barMax, stops[0], 0, CornerFlags_None)
+		}
+		canvas.AddTextF(imgui.Vec2{X: barMax.X + 4, Y: barMin.Y}, textColor, maxLabel)
+		canvas.AddTextF(imgui.Vec2{X: barMax.X + 4, Y: barMax.Y - lineHeight}, textColor, minLabel)
+	}
+
+	s := h.state
+	s.hovered = false
+	if imgui.IsItemHovered() {
+		mouse := imgui.MousePos().Minus(pos)
+		if mouse.X >= 0 && mouse.Y >= 0 && mouse.X < mapSize.X && mouse.Y < mapSize.Y {
+			s.hovered = true
+			s.col = int(mouse.X / cell.X)
+			s.row = int(mouse.Y / cell.Y)
+			if s.col >= h.cols {
+				s.col = h.cols - 1
+			}
+			if s.row >= h.rows {
+				s.row = h.rows - 1
+			}
+
+			cellMin := pos.Plus(imgui.Vec2{X: float32(s.col) * cell.X, Y: float32(s.row) * cell.Y})
+			canvas.AddRectF(cellMin, cellMin.Plus(cell), textColor, 0, CornerFlags_None, 1)
+			imgui.SetTooltip(fmt.Sprintf("row %d, col %d: "+h.format, s.row, s.col, values[s.row*h.cols+s.col]))
+		}
+	}
+}
+
+// Histogram2D counts the points xs[i], ys[i] in a grid of rows times cols cells spanning xMin to xMax
+// and yMin to yMax, e.g. to show their density with Heatmap. Row 0 holds the largest y values.
+// Points outside of the grid are left out.
+func Histogram2D(xs, ys []float64, rows, cols int, xMin, xMax, yMin, yMax float64) []float64 {
+	if rows <= 0 || cols <= 0 {
+		return nil
+	}
+	counts := make([]float64, rows*cols)
+	// Written negated so NaN bounds count nothing too.
+	if !(xMax > xMin) || !(yMax > yMin) {
+		return counts
+	}
+
+	for i := 0; i < len(xs) && i < len(ys); i++ {
+		c := int((xs[i] - xMin) / (xMax - xMin) * float64(cols))
+		r := int((yMax - ys[i]) / (yMax - yMin) * float64(rows))
+		// Points on the maximum belong to the last cell.
+		if xs[i] == xMax {
+			c = cols - 1
+		}
+		if ys[i] == yMin {
+			r = rows - 1
+		}
+		if xs[i] >= xMin && ys[i] <= yMax && c >= 0 && c < cols && r >= 0 && r < rows {
+			counts[r*cols+c]++
+		}
+	}
+	return counts
+}
+
+// contrastColor returns black or white, whichever is more readable on col.
+func contrastColor(col color.RGBA) color.RGBA {
+	if 0.299*float64(col.R)+0.587*float64(col.G)+0.114*float64(col.B) > 140 {
+		return color.RGBA{0, 0, 0, 255}
+	}
+	return color.RGBA{255, 255, 255, 255}
+}
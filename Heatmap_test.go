@@ -0,0 +1,70 @@
+package giu
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram2D(t *testing.T) {
+	tt := []struct {
+		name       string
+		xs, ys     []float64
+		rows, cols int
+		want       []float64
+	}{
+		{
+			// Row 0 holds the largest y values.
+			name: "cells",
+			xs:   []float64{0.1, 0.6, 0.6, 0.1},
+			ys:   []float64{0.1, 0.1, 0.9, 0.6},
+			rows: 2, cols: 2,
+			want: []float64{1, 1, 1, 1},
+		},
+		{
+			name: "density",
+			xs:   []float64{0.1, 0.2, 0.3, 0.9},
+			ys:   []float64{0.9, 0.8, 0.7, 0.1},
+			rows: 2, cols: 2,
+			want: []float64{3, 0, 0, 1},
+		},
+		{
+			name: "points on the bounds",
+			xs:   []float64{0, 1, 0, 1, 0.5},
+			ys:   []float64{0, 0, 1, 1, 0.5},
+			rows: 2, cols: 2,
+			want: []float64{1, 1, 1, 2},
+		},
+		{
+			name: "points outside",
+			xs:   []float64{-0.01, 1.01, 0.5, 0.5, math.NaN(), math.Inf(1)},
+			ys:   []float64{0.5, 0.5, -0.01, 1.01, 0.5, 0.5},
+			rows: 2, cols: 2,
+			want: []float64{0, 0, 0, 0},
+		},
+		{
+			name: "more xs than ys",
+			xs:   []float64{0.1, 0.9},
+			ys:   []float64{0.1},
+			rows: 1, cols: 2,
+			want: []float64{1, 0},
+		},
+		{name: "no points", rows: 1, cols: 3, want: []float64{0, 0, 0}},
+		{name: "no rows", xs: []float64{0.5}, ys: []float64{0.5}, rows: 0, cols: 2},
+		{name: "negative size", xs: []float64{0.5}, ys: []float64{0.5}, rows: -1, cols: 2},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			assert.Equal(t, td.want, Histogram2D(td.xs, td.ys, td.rows, td.cols, 0, 1, 0, 1))
+		})
+	}
+}
+
+func TestHistogram2DEmptyRange(t *testing.T) {
+	xs, ys := []float64{1, 2}, []float64{1, 2}
+	assert.Equal(t, []float64{0, 0}, Histogram2D(xs, ys, 1, 2, 1, 1, 0, 3))
+	assert.Equal(t, []float64{0, 0}, Histogram2D(xs, ys, 1, 2, 0, 3, 3, 0))
+	assert.Equal(t, []float64{0, 0}, Histogram2D(xs, ys, 1, 2, math.NaN(), 3, 0, 3))
+}
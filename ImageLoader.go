@@ -0,0 +1,173 @@
+package giu
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ImageCache keeps the textures loaded by ImageWithFile and ImageWithURL, 256MB by default.
+// Textures of images being shown are never evicted. Use SetBudget to change it and Stats to watch it.
+var ImageCache = NewTextureCache(256 << 20)
+
+const (
+	defaultImageLoadTimeout = 10 * time.Second
+	// imageRetryDelay is how long a failed load is reported before the image is loaded again.
+	imageRetryDelay = 30 * time.Second
+)
+
+type imageLoadState struct {
+	err    error
+	failed time.Time
+}
+
+var imageLoader = struct {
+	mu     sync.Mutex
+	states map[string]*imageLoadState
+
+	// Textures of images shown in the last frame are acquired, so they aren't evicted and loaded again every frame.
+	pinned map[string]*TextureRef
+	drawn  map[string]bool
+}{
+	states: make(map[string]*imageLoadState),
+	pinned: make(map[string]*TextureRef),
+	drawn:  make(map[string]bool),
+}
+
+// loadImageAsync returns the texture stored in ImageCache with key, or starts loading it with fetch.
+// While the image is loading both the texture and the error are nil.
+// A failed load is retried once its error was reported for imageRetryDelay.
+func loadImageAsync(key string, timeout time.Duration, fetch func(timeout time.Duration) ([]byte, error)) (*Texture, error) {
+	imageLoader.mu.Lock()
+	defer imageLoader.mu.Unlock()
+
+	ref, ok := imageLoader.pinned[key]
+	if ok && !ref.valid() {
+		// The texture was removed from the cache, e.g. to load the image again.
+		ref.Release()
+		delete(imageLoader.pinned, key)
+		ok = false
+	}
+	if !ok {
+		ref, ok = ImageCache.Acquire(key)
+	}
+	if ok {
+		imageLoader.pinned[key] = ref
+		imageLoader.drawn[key] = true
+		return ref.Texture(), nil
+	}
+
+	if state, ok := imageLoader.states[key]; ok {
+		if state.err == nil || time.Since(state.failed) < imageRetryDelay {
+			return nil, state.err
+		}
+	}
+	forgetFailedImages()
+
+	state := &imageLoadState{}
+	imageLoader.states[key] = state
+
+	go func() {
+		texture, err := fetchTexture(timeout, fetch)
+
+		imageLoader.mu.Lock()
+		if err != nil {
+			state.err = err
+			state.failed = time.Now()
+		} else {
+			// Acquired right away, or it could be evicted before the next frame shows it and be loaded again.
+			// It counts as drawn, so it's kept until the end of the next frame.
+			if ref, ok := imageLoader.pinned[key]; ok {
+				ref.Release()
+			}
+			imageLoader.pinned[key] = ImageCache.putAcquired(key, texture)
+			imageLoader.drawn[key] = true
+			delete(imageLoader.states, key)
+		}
+		imageLoader.mu.Unlock()
+
+		Update()
+	}()
+
+	return nil, nil
+}
+
+// forgetFailedImages drops the errors of failed loads which are retried by now, so they don't pile up.
+func forgetFailedImages() {
+	for key, state := range imageLoader.states {
+		if state.err != nil && time.Since(state.failed) >= imageRetryDelay {
+			delete(imageLoader.states, key)
+		}
+	}
+}
+
+// releaseHiddenImages releases the textures of images which weren't shown in the frame just built,
+// so ImageCache may evict them again. It must be called after every frame.
+func releaseHiddenImages() {
+	imageLoader.mu.Lock()
+	defer imageLoader.mu.Unlock()
+
+	for key, ref := range imageLoader.pinned {
+		if !imageLoader.drawn[key] {
+			ref.Release()
+			delete(imageLoader.pinned, key)
+		}
+	}
+	for key := range imageLoader.drawn {
+		delete(imageLoader.drawn, key)
+	}
+}
+
+func fetchTexture(timeout time.Duration, fetch func(timeout time.Duration) ([]byte, error)) (*Texture, error) {
+	type fetchResult struct {
+		data []byte
+		err  error
+	}
+
+	done := make(chan fetchResult, 1)
+	go func() {
+		data, err := fetch(timeout)
+		done <- fetchResult{data: data, err: err}
+	}()
+
+	var result fetchResult
+	select {
+	case result = <-done:
+	case <-time.After(timeout):
+		return nil, errors.New("loading image timed out")
+	}
+
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	rgba, err := LoadImageFromBytes(result.data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTextureFromRgba(rgba)
+}
+
+func fetchFile(path string) func(time.Duration) ([]byte, error) {
+	return func(time.Duration) ([]byte, error) {
+		return ioutil.ReadFile(path)
+	}
+}
+
+func fetchURL(url string) func(time.Duration) ([]byte, error) {
+	return func(timeout time.Duration) ([]byte, error) {
+		resp, err := resty.New().SetTimeout(timeout).R().Get(url)
+		if err != nil {
+			return nil, err
+		}
+		if resp.IsError() {
+			return nil, fmt.Errorf("loading image from %s: %s", url, resp.Status())
+		}
+		return resp.Body(), nil
+	}
+}
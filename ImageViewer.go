@@ -0,0 +1,217 @@
+package giu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// ImageViewerMode selects how an ImageViewer places the image.
+type ImageViewerMode int
+
+const (
+	// ImageViewerModeFit shows the whole image, as large as the viewer allows.
+	ImageViewerModeFit ImageViewerMode = iota
+	// ImageViewerModeFill covers the whole viewer with the image, cropping it.
+	ImageViewerModeFill
+	// ImageViewerModeActualSize shows one image pixel per screen pixel.
+	ImageViewerModeActualSize
+	// ImageViewerModeFree keeps the zoom and position set by the user.
+	ImageViewerModeFree
+)
+
+const (
+	imageViewerMinZoom  = 1.0 / 64
+	imageViewerMaxZoom  = 256
+	imageViewerZoomStep = 1.25
+)
+
+// ImageViewerState keeps the zoom and position of an ImageViewer between frames.
+// The zero value fits the image into the viewer.
+type ImageViewerState struct {
+	mode   ImageViewerMode
+	zoom   float32
+	center imgui.Vec2 // image coordinate shown in the center of the viewer
+
+	hovered bool
+	pixel   image.Point
+}
+
+// Mode returns the current mode, it becomes ImageViewerModeFree once the user zooms or pans.
+func (s *ImageViewerState) Mode() ImageViewerMode {
+	return s.mode
+}
+
+// SetMode places the image according to mode from the next frame on.
+func (s *ImageViewerState) SetMode(mode ImageViewerMode) {
+	s.mode = mode
+}
+
+// Zoom returns the count of screen pixels per image pixel.
+func (s *ImageViewerState) Zoom() float32 {
+	return s.zoom
+}
+
+// SetZoom zooms around the center of the viewer.
+func (s *ImageViewerState) SetZoom(zoom float32) {
+	s.zoom = clampZoom(zoom)
+	s.mode = ImageViewerModeFree
+}
+
+// HoveredPixel returns the image pixel under the mouse cursor, ok is false if the cursor is not over the image.
+func (s *ImageViewerState) HoveredPixel() (pixel image.Point, ok bool) {
+	return s.pixel, s.hovered
+}
+
+func clampZoom(zoom float32) float32 {
+	return float32(math.Max(imageViewerMinZoom, math.Min(imageViewerMaxZoom, float64(zoom))))
+}
+
+type ImageViewerWidget struct {
+	id          string
+	texture     *Texture
+	state       *ImageViewerState
+	width       float32
+	height      float32
+	showReadout bool
+}
+
+// ImageViewer shows texture for inspection: the mouse wheel zooms around the cursor,
+// dragging with the left button pans, and double clicking fits the image again.
+// The pixel under the cursor is shown in the lower left corner.
+// Zoom and position are kept in state, which has to live as long as the viewer is shown.
+//
+// Note: the mouse wheel scrolls the parent window too if it has a scrollbar,
+// use imgui.WindowFlagsNoScrollWithMouse on it.
+func ImageViewer(id string, texture *Texture, state *ImageViewerState, width, height float32) *ImageViewerWidget {
+	return ImageViewerV(id, texture, state, width, height, true)
+}
+
+// ImageViewerV works like ImageViewer, showReadout toggles the pixel coordinate readout.
+func ImageViewerV(id string, texture *Texture, state *ImageViewerState, width, height float32, showReadout bool) *ImageViewerWidget {
+	return &ImageViewerWidget{
+		id:          id,
+		texture:     texture,
+		state:       state,
+		width:       width,
+		height:      height,
+		showReadout: showReadout,
+	}
+}
+
+func (v *ImageViewerWidget) Build() {
+	size := imgui.Vec2{X: v.width, Y: v.height}
+	rect := imgui.ContentRegionAvail()
+	if size.X == -1 {
+		size.X = rect.X
+	}
+	if size.Y == -1 {
+		size.Y = rect.Y
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButton(v.id, size)
+
+	if v.texture == nil || v.texture.id == 0 || v.state == nil {
+		return
+	}
+
+	s := v.state
+	texWidth, texHeight := v.texture.Size()
+	texSize := imgui.Vec2{X: float32(texWidth), Y: float32(texHeight)}
+	viewCenter := pos.Plus(size.Times(0.5))
+	mouse := imgui.MousePos()
+	hovered := imgui.IsItemHovered()
+
+	if hovered && imgui.IsMouseDoubleClicked(0) {
+		s.mode = ImageViewerModeFit
+	}
+
+	switch s.mode {
+	case ImageViewerModeFit:
+		s.zoom = float32(math.Min(float64(size.X/texSize.X), float64(size.Y/texSize.Y)))
+		s.center = texSize.Times(0.5)
+	case ImageViewerModeFill:
+		s.zoom = float32(math.Max(float64(size.X/texSize.X), float64(size.Y/texSize.Y)))
+		s.center = texSize.Times(0.5)
+	case ImageViewerModeActualSize:
+		s.zoom = 1
+		s.center = texSize.Times(0.5)
+	}
+	if s.zoom <= 0 {
+		s.zoom = 1
+		s.center = texSize.Times(0.5)
+	}
+
+	if hovered {
+		if _, wheel := Context.IO().GetMouseWheel(); wheel != 0 {
+			// Keep the image point under the cursor in place.
+			offset := mouse.Minus(viewCenter)
+			anchor := s.center.Plus(offset.Times(1 / s.zoom))
+			s.zoom = clampZoom(s.zoom * float32(math.Pow(imageViewerZoomStep, float64(wheel))))
+			s.center = anchor.Minus(offset.Times(1 / s.zoom))
+			s.mode = ImageViewerModeFree
+		}
+	}
+
+	if imgui.IsItemActive() {
+		if delta := Context.IO().GetMouseDelta(); delta.X != 0 || delta.Y != 0 {
+			s.center = s.center.Minus(delta.Times(1 / s.zoom))
+			s.mode = ImageViewerModeFree
+		}
+	}
+
+	// Screen rectangle of the whole image, cropped to the viewer.
+	imgMin := viewCenter.Minus(s.center.Times(s.zoom))
+	imgMax := imgMin.Plus(texSize.Times(s.zoom))
+	viewMax := pos.Plus(size)
+	drawMin := imgui.Vec2{X: maxf(imgMin.X, pos.X), Y: maxf(imgMin.Y, pos.Y)}
+	drawMax := imgui.Vec2{X: minf(imgMax.X, viewMax.X), Y: minf(imgMax.Y, viewMax.Y)}
+
+	drawList := imgui.GetWindowDrawList()
+	if drawMin.X < drawMax.X && drawMin.Y < drawMax.Y {
+		imgSize := texSize.Times(s.zoom)
+		uvMin := imgui.Vec2{X: (drawMin.X - imgMin.X) / imgSize.X, Y: (drawMin.Y - imgMin.Y) / imgSize.Y}
+		uvMax := imgui.Vec2{X: (drawMax.X - imgMin.X) / imgSize.X, Y: (drawMax.Y - imgMin.Y) / imgSize.Y}
+		drawList.AddImage(v.texture.id, drawMin, drawMax, uvMin, uvMax, imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1})
+	}
+
+	s.hovered = false
+	if hovered {
+		p := s.center.Plus(mouse.Minus(viewCenter).Times(1 / s.zoom))
+		if p.X >= 0 && p.Y >= 0 && p.X < texSize.X && p.Y < texSize.Y {
+			s.hovered = true
+			s.pixel = image.Pt(int(p.X), int(p.Y))
+		}
+	}
+
+	if v.showReadout && s.hovered {
+		text := fmt.Sprintf("%d, %d  %.0f%%", s.pixel.X, s.pixel.Y, s.zoom*100)
+		textWidth, textHeight := CalcTextSize(text)
+		padding := imgui.Vec2{X: 4, Y: 2}
+		textPos := imgui.Vec2{X: pos.X + padding.X, Y: viewMax.Y - textHeight - padding.Y}
+		drawList.AddRectFilled(textPos.Minus(padding), textPos.Plus(imgui.Vec2{X: textWidth, Y: textHeight}).Plus(padding),
+			ToVec4Color(color.RGBA{0, 0, 0, 160}), 0, 0)
+		drawList.AddText(textPos, imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}, text)
+	}
+}
+
+func minf(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxf(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,174 @@
+package giu
+
+import (
+	"strconv"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// ListViewState keeps the selected item of a ListView between frames.
+type ListViewState struct {
+	selected    int
+	hasSelected bool
+	scrollTo    int
+	scroll      bool
+}
+
+// Selected returns the selected item, ok is false if there is none.
+func (s *ListViewState) Selected() (index int, ok bool) {
+	return s.selected, s.hasSelected
+}
+
+// SetSelected selects the item at index.
+func (s *ListViewState) SetSelected(index int) {
+	s.selected, s.hasSelected = index, true
+}
+
+// ClearSelection deselects the selected item.
+func (s *ListViewState) ClearSelection() {
+	s.hasSelected = false
+}
+
+// ScrollTo scrolls the list in the next frame so the item at index is visible.
+func (s *ListViewState) ScrollTo(index int) {
+	s.scrollTo, s.scroll = index, true
+}
+
+type ListViewWidget struct {
+	id        string
+	state     *ListViewState
+	count     int
+	rowHeight float32
+	width     float32
+	height    float32
+	onSelect  func(index int)
+	builder   func(i int) Widget
+}
+
+// ListView shows count items of rowHeight in a scrolling area filling the available space,
+// builder is only called for visible items so the count doesn't matter. A rowHeight <= 0 fits a line of text.
+func ListView(id string, count int, rowHeight float32, builder func(i int) Widget) *ListViewWidget {
+	return ListViewV(id, nil, count, rowHeight, -1, -1, nil, builder)
+}
+
+// ListViewV works like ListView with a size, width or height -1 fill the available space.
+// With a state, items are selected by clicks or the arrow, page, home and end keys and onSelect is invoked with the selected index.
+func ListViewV(id string, state *ListViewState, count int, rowHeight float32, width, height float32, onSelect func(index int), builder func(i int) Widget) *ListViewWidget {
+	return &ListViewWidget{
+		id:        id,
+		state:     state,
+		count:     count,
+		rowHeight: rowHeight,
+		width:     width,
+		height:    height,
+		onSelect:  onSelect,
+		builder:   builder,
+	}
+}
+
+func (l *ListViewWidget) Build() {
+	rowHeight := l.rowHeight
+	if rowHeight <= 0 {
+		rowHeight = imgui.TextLineHeightWithSpacing()
+	}
+
+	imgui.BeginChildV(l.id, resolveSize(l.width, l.height), false, 0)
+	s := l.state
+	if s != nil {
+		l.handleKeys(s, rowHeight)
+		if s.scroll {
+			s.scroll = false
+			l.reveal(s.scrollTo, rowHeight)
+		}
+	}
+
+	// The hit area of selectables grows by the item spacing, so rows of selectables touch without overlapping.
+	selectableHeight := rowHeight - imgui.CurrentStyle().ItemSpacing().Y
+
+	var clipper imgui.ListClipper
+	clipper.BeginV(l.count, rowHeight)
+	for clipper.Step() {
+		for i := clipper.DisplayStart; i < clipper.DisplayEnd; i++ {
+			rowPos := imgui.CursorPos()
+			imgui.PushID(strconv.Itoa(i))
+			if s != nil {
+				selected := s.hasSelected && s.selected == i
+				if imgui.SelectableV("##item", selected, 0, imgui.Vec2{X: 0, Y: selectableHeight}) {
+					l.selectItem(s, i)
+				}
+				imgui.SetItemAllowOverlap()
+				imgui.SetCursorPos(rowPos)
+			}
+			if w := l.builder(i); w != nil {
+				w.Build()
+			}
+			imgui.PopID()
+			// Keep rows evenly spaced whatever the height of their widgets, the clipper relies on it.
+			imgui.SetCursorPos(imgui.Vec2{X: rowPos.X, Y: rowPos.Y + rowHeight})
+		}
+	}
+	imgui.EndChild()
+}
+
+func (l *ListViewWidget) handleKeys(s *ListViewState, rowHeight float32) {
+	if l.count == 0 || !imgui.IsWindowFocused() {
+		return
+	}
+
+	page := int(imgui.WindowHeight()/rowHeight) - 1
+	if page < 1 {
+		page = 1
+	}
+	index := s.selected
+	if !s.hasSelected {
+		index = -1
+	}
+	switch {
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyDownArrow)):
+		index++
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyUpArrow)):
+		index--
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyPageDown)):
+		index += page
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyPageUp)):
+		index -= page
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyHome)):
+		index = 0
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyEnd)):
+		index = l.count - 1
+	default:
+		return
+	}
+
+	if index < 0 {
+		index = 0
+	}
+	if index >= l.count {
+		index = l.count - 1
+	}
+	if s.hasSelected && index == s.selected {
+		return
+	}
+	l.selectItem(s, index)
+	l.reveal(index, rowHeight)
+}
+
+func (l *ListViewWidget) selectItem(s *ListViewState, index int) {
+	s.SetSelected(index)
+	if l.onSelect != nil {
+		l.onSelect(index)
+	}
+}
+
+// reveal scrolls the least needed to show the item at index.
+func (l *ListViewWidget) reveal(index int, rowHeight float32) {
+	top := float32(index) * rowHeight
+	scroll := imgui.ScrollY()
+	visible := imgui.WindowHeight()
+	switch {
+	case top < scroll:
+		imgui.SetScrollY(top)
+	case top+rowHeight > scroll+visible:
+		imgui.SetScrollY(top + rowHeight - visible)
+	}
+}
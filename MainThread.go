@@ -2,6 +2,7 @@ package giu
 
 import (
 	"errors"
+	"sync"
 )
 
 // CallQueueCap is the capacity of the call queue. This means how many calls to CallNonBlock will not
@@ -80,3 +81,31 @@ func CallVal(f func() interface{}) interface{} {
 	}
 	return <-respChan
 }
+
+var (
+	frameTasksMutex sync.Mutex
+	frameTasks      []func()
+)
+
+// callBetweenFrames queues function f on the main thread, it runs before the next frame is built.
+// Unlike Call it never blocks, so it is safe to use from the ui loop as well.
+func callBetweenFrames(f func()) {
+	frameTasksMutex.Lock()
+	frameTasks = append(frameTasks, f)
+	frameTasksMutex.Unlock()
+
+	if Context.platform != nil {
+		Update()
+	}
+}
+
+func runFrameTasks() {
+	frameTasksMutex.Lock()
+	tasks := frameTasks
+	frameTasks = nil
+	frameTasksMutex.Unlock()
+
+	for _, f := range tasks {
+		f()
+	}
+}
@@ -18,6 +18,11 @@ type MasterWindow struct {
 	context    *imgui.Context
 	io         *imgui.IO
 	updateFunc func()
+
+	scale        float32
+	contentScale float32
+	// unscaledStyle keeps the sizes of the theme, scaled anew for every content scale.
+	unscaledStyle imgui.Style
 }
 
 // Create a master window.
@@ -44,6 +49,10 @@ func NewMasterWindowWithBgColor(title string, width, height int, resizable bool,
 		panic(err)
 	}
 
+	// Fonts have to be rasterized for the monitor before the renderer creates the font texture.
+	Fonts.setScale(p.ContentScale(), 1/framebufferRatio(p))
+	Fonts.load(io)
+
 	r, err := imgui.NewOpenGL3(io)
 	if err != nil {
 		panic(err)
@@ -70,11 +79,16 @@ func NewMasterWindowWithBgColor(title string, width, height int, resizable bool,
 		context:    context,
 		platform:   p,
 		renderer:   r,
+		scale:      1,
 	}
 
 	p.SetSizeChangeCallback(mw.sizeChange)
 
 	mw.setTheme()
+	mw.unscaledStyle = imgui.CurrentStyle().Copy()
+
+	mw.setContentScale(p.ContentScale())
+	p.SetContentScaleChangeCallback(mw.setContentScale)
 
 	return mw
 }
@@ -142,6 +156,53 @@ func (w *MasterWindow) SetBgColor(color color.RGBA) {
 	w.clearColor = [4]float32{float32(color.R) / 255.0, float32(color.G) / 255.0, float32(color.B) / 255.0, float32(color.A) / 255.0}
 }
 
+// framebufferRatio returns how many framebuffer pixels there are per window pixel, e.g. 2 on MacOS retina.
+func framebufferRatio(p imgui.Platform) float32 {
+	displaySize := p.DisplaySize()
+	framebufferSize := p.FramebufferSize()
+	if displaySize[0] > 0 && framebufferSize[0] > 0 {
+		return framebufferSize[0] / displaySize[0]
+	}
+	return 1
+}
+
+// setContentScale scales fonts and style sizes for a monitor with given content scale.
+// On systems where the framebuffer is already larger than the window (e.g. MacOS retina),
+// fonts of Fonts are rasterized at the higher resolution and drawn scaled down, so only they get sharper.
+// Fonts added in the loadFontFunc of NewMasterWindow are left as they are.
+// Style sizes are scaled from the theme, so sizes changed on imgui.CurrentStyle are replaced when the scale changes.
+func (w *MasterWindow) setContentScale(contentScale float32) {
+	ratio := framebufferRatio(w.platform)
+
+	scale := contentScale / ratio
+	if scale <= 0 {
+		scale = 1
+	}
+
+	// Scaled from the unscaled sizes, since scaling again would round them down a little more every time.
+	if scale != w.scale {
+		imgui.CurrentStyle().ScaleAllSizesFrom(w.unscaledStyle, scale)
+	}
+	Fonts.setScale(contentScale, 1/ratio)
+
+	w.scale = scale
+	w.contentScale = contentScale
+	Context.scale = scale
+	Context.contentScale = contentScale
+}
+
+// GetScale returns the factor the UI is scaled by for the monitor the window is on.
+// Custom drawing on Canvas should multiply its pixel sizes by it.
+func (w *MasterWindow) GetScale() float32 {
+	return w.scale
+}
+
+// GetContentScale returns the content scale of the monitor the window is on,
+// which is the ratio between its DPI and the platform's default DPI.
+func (w *MasterWindow) GetContentScale() float32 {
+	return w.contentScale
+}
+
 func (w *MasterWindow) sizeChange(width, height int) {
 	w.render()
 }
@@ -150,14 +211,21 @@ func (w *MasterWindow) render() {
 	p := w.platform
 	r := w.renderer
 
+	runFrameTasks()
+
+	// Apply queued font changes before the atlas gets locked by the new frame.
+	Fonts.rebuild(*w.io, r)
+
 	p.NewFrame()
 	imgui.NewFrame()
 
 	w.updateFunc()
+	releaseHiddenImages()
 
 	imgui.Render()
 	r.PreRender(w.clearColor)
 	r.Render(p.DisplaySize(), p.FramebufferSize(), imgui.RenderedDrawData())
+	runCaptures(imgui.RenderedDrawData())
 	p.PostRender()
 
 }
@@ -203,6 +271,7 @@ func (w *MasterWindow) Main(loopFunc func()) {
 			w.renderer.Dispose()
 			w.platform.Dispose()
 			w.context.Destroy()
+			w.unscaledStyle.Destroy()
 		})
 	})
 }
@@ -0,0 +1,267 @@
+package giu
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// PieSlice is a labeled value of a PieChart, a zero Color picks one from the default plot palette.
+type PieSlice struct {
+	Label string
+	Value float64
+	Color color.RGBA
+}
+
+const (
+	pieStartAngle   = -math.Pi / 2
+	pieExplode      = 6
+	pieLeaderLength = 12
+	// Slices are filled in pieces of at most this angle, so every piece is convex.
+	pieMaxPieceAngle = math.Pi / 32
+)
+
+type PieChartWidget struct {
+	id         string
+	slices     []PieSlice
+	size       float32
+	holeRatio  float32
+	showLabels bool
+	showLegend bool
+	onClick    func(index int)
+}
+
+// PieChart shows slices as shares of a circle of diameter size, with percentages outside and a legend.
+// The slice under the mouse cursor is moved out and its value shown in a tooltip. Slices with values <= 0 are left out.
+func PieChart(id string, slices []PieSlice, size float32) *PieChartWidget {
+	return PieChartV(id, slices, size, 0, true, true, nil)
+}
+
+// PieChartV works like PieChart, holeRatio > 0 makes a donut with a hole of that fraction of the diameter.
+// onClick is invoked with the index of a slice clicked in the chart or the legend.
+func PieChartV(id string, slices []PieSlice, size float32, holeRatio float32, showLabels, showLegend bool, onClick func(index int)) *PieChartWidget {
+	return &PieChartWidget{
+		id:         id,
+		slices:     slices,
+		size:       size,
+		holeRatio:  holeRatio,
+		showLabels: showLabels,
+		showLegend: showLegend,
+		onClick:    onClick,
+	}
+}
+
+type pieLabel struct {
+	index int
+	text  string
+	angle float64
+	y     float32
+	right bool
+}
+
+func (p *PieChartWidget) color(i int) color.RGBA {
+	if c := p.slices[i].Color; c.A > 0 {
+		return c
+	}
+	return plotPalette[i%len(plotPalette)]
+}
+
+func (p *PieChartWidget) Build() {
+	colors := currentDashboardColors()
+	lineHeight := imgui.CalcTextSize("", false, 0).Y
+
+	total := 0.0
+	for _, s := range p.slices {
+		if s.Value > 0 {
+			total += s.Value
+		}
+	}
+
+	// Margins for the percentages around the pie, and the legend on the right.
+	labelWidth := float32(0)
+	labels := make([]string, len(p.slices))
+	for i, s := range p.slices {
+		if s.Value > 0 && total > 0 {
+			labels[i] = fmt.Sprintf("%.1f%%", s.Value/total*100)
+			w, _ := CalcTextSize(labels[i])
+			labelWidth = maxf(labelWidth, w)
+		}
+	}
+	margin := imgui.Vec2{X: pieExplode + 2, Y: pieExplode + 2}
+	if p.showLabels {
+		margin = imgui.Vec2{X: labelWidth + pieLeaderLength*2 + 4, Y: lineHeight + pieLeaderLength}
+	}
+	legendWidth := float32(0)
+	if p.showLegend {
+		for _, s := range p.slices {
+			w, _ := CalcTextSize(s.Label)
+			legendWidth = maxf(legendWidth, w+lineHeight+8)
+		}
+	}
+
+	pieSize := imgui.Vec2{X: p.size + margin.X*2, Y: p.size + margin.Y*2}
+	size := imgui.Vec2{X: pieSize.X + legendWidth, Y: maxf(pieSize.Y, lineHeight*float32(len(p.slices)))}
+
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButton(p.id, size)
+	if total <= 0 || p.size <= 0 {
+		return
+	}
+	hovered := imgui.IsItemHovered()
+	clicked := imgui.IsItemClicked()
+	mouse := imgui.MousePos()
+
+	center := pos.Plus(pieSize.Times(0.5))
+	radius := p.size / 2
+	inner := radius * float32(math.Max(0, math.Min(0.95, float64(p.holeRatio))))
+
+	// Angles of the slices, clockwise from the top.
+	starts := make([]float64, len(p.slices))
+	ends := make([]float64, len(p.slices))
+	angle := float64(pieStartAngle)
+	for i, s := range p.slices {
+		starts[i] = angle
+		if s.Value > 0 {
+			angle += s.Value / total * 2 * math.Pi
+		}
+		ends[i] = angle
+	}
+
+	// Find the hovered slice, over the pie or its legend entry.
+	hoveredSlice := -1
+	legendPos := imgui.Vec2{X: pos.X + pieSize.X, Y: pos.Y + (size.Y-lineHeight*float32(len(p.slices)))/2}
+	if hovered {
+		d := mouse.Minus(center)
+		dist := float32(math.Hypot(float64(d.X), float64(d.Y)))
+		if dist >= inner && dist <= radius+pieExplode {
+			a := math.Atan2(float64(d.Y), float64(d.X))
+			for a < pieStartAngle {
+				a += 2 * math.Pi
+			}
+			for i := range p.slices {
+				if p.slices[i].Value > 0 && a >= starts[i] && a < ends[i] {
+					hoveredSlice = i
+				}
+			}
+		}
+		if p.showLegend && mouse.X >= legendPos.X && mouse.Y >= legendPos.Y {
+			if i := int((mouse.Y - legendPos.Y) / lineHeight); i < len(p.slices) {
+				hoveredSlice = i
+			}
+		}
+	}
+	if hoveredSlice >= 0 && p.slices[hoveredSlice].Value <= 0 {
+		hoveredSlice = -1
+	}
+
+	canvas := GetCanvas()
+	for i, s := range p.slices {
+		if s.Value <= 0 {
+			continue
+		}
+		c := center
+		if i == hoveredSlice {
+			mid := (starts[i] + ends[i]) / 2
+			c = c.Plus(imgui.Vec2{X: float32(math.Cos(mid)) * pieExplode, Y: float32(math.Sin(mid)) * pieExplode})
+		}
+		p.fillSlice(canvas, c, inner, radius, starts[i], ends[i], p.color(i))
+	}
+
+	if p.showLabels {
+		p.drawLabels(canvas, center, radius, starts, ends, labels, lineHeight, colors.text)
+	}
+
+	if p.showLegend {
+		for i, s := range p.slices {
+			rowPos := legendPos.Plus(imgui.Vec2{X: 8, Y: float32(i) * lineHeight})
+			box := lineHeight * 0.7
+			boxMin := rowPos.Plus(imgui.Vec2{X: 0, Y: (lineHeight - box) / 2})
+			canvas.AddRectFilledF(boxMin, boxMin.Plus(imgui.Vec2{X: box, Y: box}), p.color(i), 0, CornerFlags_None)
+			textColor := colors.text
+			if i != hoveredSlice && hoveredSlice >= 0 {
+				textColor = colors.textDisabled
+			}
+			canvas.AddTextF(rowPos.Plus(imgui.Vec2{X: lineHeight, Y: 0}), textColor, s.Label)
+		}
+	}
+
+	if hoveredSlice >= 0 {
+		s := p.slices[hoveredSlice]
+		imgui.SetTooltip(fmt.Sprintf("%s: %g (%.1f%%)", s.Label, s.Value, s.Value/total*100))
+		if clicked && p.onClick != nil {
+			p.onClick(hoveredSlice)
+		}
+	}
+}
+
+// fillSlice fills the slice from angle a0 to a1 in convex pieces, wedges of a pie or quads of a donut.
+func (p *PieChartWidget) fillSlice(canvas *Canvas, center imgui.Vec2, inner, radius float32, a0, a1 float64, col color.RGBA) {
+	pieces := int(math.Ceil((a1 - a0) / pieMaxPieceAngle))
+	if inner <= 0 {
+		// Wedges up to a quarter circle are convex.
+		pieces = int(math.Ceil((a1 - a0) / (math.Pi / 2)))
+	}
+	step := (a1 - a0) / float64(pieces)
+	for i := 0; i < pieces; i++ {
+		from, to := float32(a0+step*float64(i)), float32(a0+step*float64(i+1))
+		if inner <= 0 {
+			canvas.PathLineToF(center)
+			canvas.PathArcToF(center, radius, from, to, int(math.Max(2, float64(to-from)/pieMaxPieceAngle)))
+		} else {
+			canvas.PathArcToF(center, radius, from, to, 1)
+			canvas.PathArcToF(center, inner, to, from, 1)
+		}
+		canvas.PathFillConvex(col)
+	}
+}
+
+// drawLabels places the percentages outside of the pie on leader lines, spread vertically so they don't overlap.
+func (p *PieChartWidget) drawLabels(canvas *Canvas, center imgui.Vec2, radius float32, starts, ends []float64, texts []string, lineHeight float32, textColor color.RGBA) {
+	var left, right []*pieLabel
+	for i, text := range texts {
+		if text == "" {
+			continue
+		}
+		mid := (starts[i] + ends[i]) / 2
+		l := &pieLabel{
+			index: i,
+			text:  text,
+			angle: mid,
+			y:     center.Y + float32(math.Sin(mid))*(radius+pieLeaderLength) - lineHeight/2,
+			right: math.Cos(mid) >= 0,
+		}
+		if l.right {
+			right = append(right, l)
+		} else {
+			left = append(left, l)
+		}
+	}
+
+	for _, side := range [][]*pieLabel{left, right} {
+		sort.Slice(side, func(i, j int) bool { return side[i].y < side[j].y })
+		for i := 1; i < len(side); i++ {
+			if side[i].y < side[i-1].y+lineHeight {
+				side[i].y = side[i-1].y + lineHeight
+			}
+		}
+
+		for _, l := range side {
+			dir := imgui.Vec2{X: float32(math.Cos(l.angle)), Y: float32(math.Sin(l.angle))}
+			start := center.Plus(dir.Times(radius + 2))
+			elbow := center.Plus(dir.Times(radius + pieLeaderLength))
+			elbow.Y = l.y + lineHeight/2
+			end := elbow.Plus(imgui.Vec2{X: pieLeaderLength, Y: 0})
+			textPos := end.Plus(imgui.Vec2{X: 2, Y: -lineHeight / 2})
+			if !l.right {
+				end = elbow.Minus(imgui.Vec2{X: pieLeaderLength, Y: 0})
+				w, _ := CalcTextSize(l.text)
+				textPos = end.Minus(imgui.Vec2{X: w + 2, Y: lineHeight / 2})
+			}
+			canvas.AddPolylineF([]imgui.Vec2{start, elbow, end}, p.color(l.index), false, 1)
+			canvas.AddTextF(textPos, textColor, l.text)
+		}
+	}
+}
@@ -0,0 +1,748 @@
+package giu
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// PlotSeriesType selects how a PlotSeries is drawn.
+type PlotSeriesType int
+
+const (
+	// PlotSeriesLine connects the points with lines.
+	PlotSeriesLine PlotSeriesType = iota
+	// PlotSeriesScatter draws a marker at every point.
+	PlotSeriesScatter
+	// PlotSeriesBars draws a bar from 0 to every point.
+	PlotSeriesBars
+	// PlotSeriesStairs holds every value until the next point.
+	PlotSeriesStairs
+	// PlotSeriesShaded fills the area between the line and 0.
+	PlotSeriesShaded
+)
+
+// PlotSeries is a named sequence of points of a Plot. X may be nil to use the indices of Y.
+// A zero Color picks one from the default palette.
+type PlotSeries struct {
+	Label      string
+	Type       PlotSeriesType
+	X, Y       []float64
+	Color      color.RGBA
+	Thickness  float32 // of lines, in pixels
+	MarkerSize float32 // radius of scatter markers, in pixels
+	BarWidth   float64 // in x units, 0 fits the distance of the points
+}
+
+// PlotLine creates a line series.
+func PlotLine(label string, x, y []float64) *PlotSeries {
+	return &PlotSeries{Label: label, Type: PlotSeriesLine, X: x, Y: y, Thickness: 1, MarkerSize: 3}
+}
+
+// PlotScatter creates a scatter series.
+func PlotScatter(label string, x, y []float64) *PlotSeries {
+	return &PlotSeries{Label: label, Type: PlotSeriesScatter, X: x, Y: y, Thickness: 1, MarkerSize: 3}
+}
+
+// PlotBars creates a bar series.
+func PlotBars(label string, x, y []float64) *PlotSeries {
+	return &PlotSeries{Label: label, Type: PlotSeriesBars, X: x, Y: y, Thickness: 1, MarkerSize: 3}
+}
+
+// PlotStairs creates a stairs series.
+func PlotStairs(label string, x, y []float64) *PlotSeries {
+	return &PlotSeries{Label: label, Type: PlotSeriesStairs, X: x, Y: y, Thickness: 1, MarkerSize: 3}
+}
+
+// PlotShaded creates a line series with the area down to 0 filled.
+func PlotShaded(label string, x, y []float64) *PlotSeries {
+	return &PlotSeries{Label: label, Type: PlotSeriesShaded, X: x, Y: y, Thickness: 1, MarkerSize: 3}
+}
+
+func (s *PlotSeries) len() int {
+	if s.X != nil && len(s.X) < len(s.Y) {
+		return len(s.X)
+	}
+	return len(s.Y)
+}
+
+func (s *PlotSeries) x(i int) float64 {
+	if s.X == nil {
+		return float64(i)
+	}
+	return s.X[i]
+}
+
+// barWidth returns BarWidth, or two thirds of the smallest distance between points.
+func (s *PlotSeries) barWidth() float64 {
+	if s.BarWidth > 0 {
+		return s.BarWidth
+	}
+	width := math.Inf(1)
+	for i := 1; i < s.len(); i++ {
+		if d := math.Abs(s.x(i) - s.x(i-1)); d > 0 {
+			width = math.Min(width, d)
+		}
+	}
+	if math.IsInf(width, 1) {
+		width = 1
+	}
+	return width * 2 / 3
+}
+
+// plotPalette holds the default series colors.
+var plotPalette = []color.RGBA{
+	{31, 119, 180, 255},
+	{255, 127, 14, 255},
+	{44, 160, 44, 255},
+	{214, 39, 40, 255},
+	{148, 103, 189, 255},
+	{140, 86, 75, 255},
+	{227, 119, 194, 255},
+	{127, 127, 127, 255},
+	{188, 189, 34, 255},
+	{23, 190, 207, 255},
+}
+
+// PlotAxisScale maps values to an axis.
+type PlotAxisScale int
+
+const (
+	PlotAxisLinear PlotAxisScale = iota
+	// PlotAxisLog spaces powers of ten evenly, values <= 0 are left out.
+	PlotAxisLog
+)
+
+func (scale PlotAxisScale) forward(v float64) float64 {
+	if scale == PlotAxisLog {
+		if v <= 0 {
+			return math.NaN()
+		}
+		return math.Log10(v)
+	}
+	return v
+}
+
+func (scale PlotAxisScale) inverse(t float64) float64 {
+	if scale == PlotAxisLog {
+		return math.Pow(10, t)
+	}
+	return t
+}
+
+const (
+	plotZoomStep      = 1.2
+	plotXTickSpacing  = 80 // minimal pixels between ticks
+	plotYTickSpacing  = 40
+	plotHoverDistance = 20
+)
+
+// PlotState keeps the visible range and the hidden series of a Plot between frames.
+// The zero value fits the axes to the data every frame, until the user zooms or pans.
+type PlotState struct {
+	// Visible range, as transformed by the axis scales.
+	xMin, xMax float64
+	yMin, yMax float64
+	userView   bool
+	hidden     map[string]bool
+
+	legendClick bool
+}
+
+// Fit makes the axes follow the data again.
+func (s *PlotState) Fit() {
+	s.userView = false
+}
+
+// SetLimits shows the given range, the axes don't follow the data until Fit is called.
+// Limits of log axes must be > 0.
+func (s *PlotState) SetLimits(xMin, xMax, yMin, yMax float64, xScale, yScale PlotAxisScale) {
+	s.xMin, s.xMax = xScale.forward(xMin), xScale.forward(xMax)
+	s.yMin, s.yMax = yScale.forward(yMin), yScale.forward(yMax)
+	s.userView = true
+}
+
+// Limits returns the range shown in the last frame.
+func (s *PlotState) Limits(xScale, yScale PlotAxisScale) (xMin, xMax, yMin, yMax float64) {
+	return xScale.inverse(s.xMin), xScale.inverse(s.xMax), yScale.inverse(s.yMin), yScale.inverse(s.yMax)
+}
+
+// IsHidden reports whether the series with label is hidden by its legend toggle.
+func (s *PlotState) IsHidden(label string) bool {
+	return s.hidden[label]
+}
+
+// SetHidden hides or shows the series with label.
+func (s *PlotState) SetHidden(label string, hidden bool) {
+	if s.hidden == nil {
+		s.hidden = make(map[string]bool)
+	}
+	s.hidden[label] = hidden
+}
+
+type PlotWidget struct {
+	id         string
+	state      *PlotState
+	width      float32
+	height     float32
+	title      string
+	xLabel     string
+	yLabel     string
+	xScale     PlotAxisScale
+	yScale     PlotAxisScale
+	showLegend bool
+	series     []*PlotSeries
+
+	fixedX bool // fit only the y axis, the x range is set by the caller
+}
+
+// Plot draws series on linear axes with a legend. Width or height -1 fill the available space.
+// The mouse wheel zooms around the cursor, only along an axis when over its labels, dragging pans,
+// and double clicking fits the axes to the data again. Clicking a legend entry hides or shows its series.
+func Plot(id string, state *PlotState, width, height float32, series ...*PlotSeries) *PlotWidget {
+	return PlotV(id, state, width, height, "", "", "", PlotAxisLinear, PlotAxisLinear, true, series...)
+}
+
+// PlotV works like Plot with a title, axis labels and scales, and the legend optional.
+func PlotV(id string, state *PlotState, width, height float32, title, xLabel, yLabel string, xScale, yScale PlotAxisScale, showLegend bool, series ...*PlotSeries) *PlotWidget {
+	return &PlotWidget{
+		id:         id,
+		state:      state,
+		width:      width,
+		height:     height,
+		title:      title,
+		xLabel:     xLabel,
+		yLabel:     yLabel,
+		xScale:     xScale,
+		yScale:     yScale,
+		showLegend: showLegend,
+		series:     series,
+	}
+}
+
+type plotTick struct {
+	pos   float64 // transformed
+	label string
+}
+
+type plotLegendEntry struct {
+	series   *PlotSeries
+	color    color.RGBA
+	min, max imgui.Vec2
+}
+
+func (p *PlotWidget) Build() {
+	size := imgui.Vec2{X: p.width, Y: p.height}
+	avail := imgui.ContentRegionAvail()
+	if size.X == -1 {
+		size.X = avail.X
+	}
+	if size.Y == -1 {
+		size.Y = avail.Y
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButton(p.id, size)
+	if p.state == nil {
+		return
+	}
+	s := p.state
+	hovered := imgui.IsItemHovered()
+	mouse := imgui.MousePos()
+
+	if hovered && imgui.IsMouseDoubleClicked(0) {
+		s.userView = false
+	}
+	// Ranges collapsed by SetLimits can't be shown, the axes follow the data instead.
+	if !s.userView || !plotRangeValid(s.xMin, s.xMax) || !plotRangeValid(s.yMin, s.yMax) {
+		p.fit()
+	}
+
+	style := imgui.CurrentStyle()
+	textColor := Vec4ToRGBA(style.GetColor(imgui.StyleColorText))
+	gridColor := textColor
+	gridColor.A = 40
+	lineHeight := imgui.CalcTextSize("", false, 0).Y
+
+	// Margins around the plot area for the title, tick labels and axis labels.
+	top := float32(6)
+	if p.title != "" {
+		top += lineHeight + 4
+	}
+	bottom := lineHeight + 6
+	if p.xLabel != "" {
+		bottom += lineHeight + 2
+	}
+	plotHeight := size.Y - top - bottom
+
+	yTicks := plotTicks(s.yMin, s.yMax, plotHeight, plotYTickSpacing, p.yScale)
+	left := float32(0)
+	for _, t := range yTicks {
+		w, _ := CalcTextSize(t.label)
+		left = maxf(left, w)
+	}
+	left += 6
+	if p.yLabel != "" {
+		left += lineHeight + 2
+	}
+	right := float32(10)
+
+	plotMin := pos.Plus(imgui.Vec2{X: left, Y: top})
+	plotMax := pos.Plus(size).Minus(imgui.Vec2{X: right, Y: bottom})
+	plotSize := plotMax.Minus(plotMin)
+	if plotSize.X <= 0 || plotSize.Y <= 0 {
+		return
+	}
+	xTicks := plotTicks(s.xMin, s.xMax, plotSize.X, plotXTickSpacing, p.xScale)
+
+	legend := p.layoutLegend(plotMin, lineHeight)
+
+	// Legend toggles take the click, the plot isn't panned by it.
+	if hovered && imgui.IsMouseClicked(0) {
+		s.legendClick = false
+		for _, e := range legend {
+			if mouse.X >= e.min.X && mouse.X <= e.max.X && mouse.Y >= e.min.Y && mouse.Y <= e.max.Y {
+				s.SetHidden(e.series.Label, !s.IsHidden(e.series.Label))
+				s.legendClick = true
+			}
+		}
+	}
+
+	overX := mouse.X >= plotMin.X && mouse.X <= plotMax.X
+	overY := mouse.Y >= plotMin.Y && mouse.Y <= plotMax.Y
+	if hovered {
+		if _, wheel := Context.IO().GetMouseWheel(); wheel != 0 {
+			factor := math.Pow(plotZoomStep, -float64(wheel))
+			if overX {
+				anchor := s.xMin + float64((mouse.X-plotMin.X)/plotSize.X)*(s.xMax-s.xMin)
+				s.xMin, s.xMax = plotZoom(s.xMin, s.xMax, anchor, factor)
+			}
+			if overY {
+				anchor := s.yMax - float64((mouse.Y-plotMin.Y)/plotSize.Y)*(s.yMax-s.yMin)
+				s.yMin, s.yMax = plotZoom(s.yMin, s.yMax, anchor, factor)
+			}
+			s.userView = true
+		}
+	}
+	if imgui.IsItemActive() && !s.legendClick {
+		if delta := Context.IO().GetMouseDelta(); delta.X != 0 || delta.Y != 0 {
+			dx := -float64(delta.X/plotSize.X) * (s.xMax - s.xMin)
+			dy := float64(delta.Y/plotSize.Y) * (s.yMax - s.yMin)
+			s.xMin, s.xMax = s.xMin+dx, s.xMax+dx
+			s.yMin, s.yMax = s.yMin+dy, s.yMax+dy
+			s.userView = true
+		}
+	}
+
+	toScreen := func(x, y float64) imgui.Vec2 {
+		return imgui.Vec2{
+			X: plotMin.X + float32((p.xScale.forward(x)-s.xMin)/(s.xMax-s.xMin))*plotSize.X,
+			Y: plotMax.Y - float32((p.yScale.forward(y)-s.yMin)/(s.yMax-s.yMin))*plotSize.Y,
+		}
+	}
+
+	canvas := GetCanvas()
+	canvas.AddRectFilledF(plotMin, plotMax, Vec4ToRGBA(style.GetColor(imgui.StyleColorFrameBg)), 0, CornerFlags_None)
+
+	// Grid and tick labels.
+	for _, t := range xTicks {
+		x := plotMin.X + float32((t.pos-s.xMin)/(s.xMax-s.xMin))*plotSize.X
+		canvas.AddLineF(imgui.Vec2{X: x, Y: plotMin.Y}, imgui.Vec2{X: x, Y: plotMax.Y}, gridColor, 1)
+		w, _ := CalcTextSize(t.label)
+		canvas.AddTextF(imgui.Vec2{X: x - w/2, Y: plotMax.Y + 3}, textColor, t.label)
+	}
+	for _, t := range yTicks {
+		y := plotMax.Y - float32((t.pos-s.yMin)/(s.yMax-s.yMin))*plotSize.Y
+		canvas.AddLineF(imgui.Vec2{X: plotMin.X, Y: y}, imgui.Vec2{X: plotMax.X, Y: y}, gridColor, 1)
+		w, _ := CalcTextSize(t.label)
+		canvas.AddTextF(imgui.Vec2{X: plotMin.X - w - 4, Y: y - lineHeight/2}, textColor, t.label)
+	}
+
+	// Only the points in view are drawn and hovered, reduced to a few per pixel column,
+	// so large series neither slow down the frame nor overflow the 16 bit indices of the draw list.
+	visible := make([][]int, len(p.series))
+	for i, series := range p.series {
+		if !s.IsHidden(series.Label) {
+			visible[i] = p.visibleIndices(series, int(math.Max(1, float64(plotSize.X))))
+		}
+	}
+
+	canvas.PushClipRectF(plotMin, plotMax, true)
+	for i, series := range p.series {
+		if !s.IsHidden(series.Label) {
+			p.drawSeries(canvas, series, visible[i], p.seriesColor(i), toScreen, plotMax.Y)
+		}
+	}
+
+	// Highlight the point nearest to the cursor.
+	if hovered && overX && overY && !imgui.IsItemActive() {
+		best := float32(plotHoverDistance * plotHoverDistance)
+		var bestSeries *PlotSeries
+		var bestIndex int
+		for j, series := range p.series {
+			for _, i := range visible[j] {
+				sp := toScreen(series.x(i), series.Y[i])
+				d := sp.Minus(mouse)
+				if dist := d.X*d.X + d.Y*d.Y; dist < best {
+					best, bestSeries, bestIndex = dist, series, i
+				}
+			}
+		}
+		if bestSeries != nil {
+			canvas.AddCircleF(toScreen(bestSeries.x(bestIndex), bestSeries.Y[bestIndex]), 5, textColor, 12, 1.5)
+			imgui.SetTooltip(fmt.Sprintf("%s\nx: %.6g\ny: %.6g", bestSeries.Label, bestSeries.x(bestIndex), bestSeries.Y[bestIndex]))
+		}
+	}
+	canvas.PopClipRect()
+
+	canvas.AddRectF(plotMin, plotMax, gridColor, 0, CornerFlags_None, 1)
+
+	if p.title != "" {
+		w, _ := CalcTextSize(p.title)
+		canvas.AddTextF(imgui.Vec2{X: plotMin.X + (plotSize.X-w)/2, Y: pos.Y + 4}, textColor, p.title)
+	}
+	if p.xLabel != "" {
+		w, _ := CalcTextSize(p.xLabel)
+		canvas.AddTextF(imgui.Vec2{X: plotMin.X + (plotSize.X-w)/2, Y: pos.Y + size.Y - lineHeight - 2}, textColor, p.xLabel)
+	}
+	if p.yLabel != "" {
+		w, _ := CalcTextSize(p.yLabel)
+		canvas.AddTextRotatedF(nil, 0, imgui.Vec2{X: pos.X + 2, Y: plotMin.Y + (plotSize.Y+w)/2}, -math.Pi/2, textColor, p.yLabel)
+	}
+
+	if len(legend) > 0 {
+		bg := Vec4ToRGBA(style.GetColor(imgui.StyleColorPopupBg))
+		legendMax := legend[len(legend)-1].max
+		for _, e := range legend {
+			legendMax.X = maxf(legendMax.X, e.max.X)
+		}
+		canvas.AddRectFilledF(legend[0].min.Minus(imgui.Vec2{X: 4, Y: 4}), legendMax.Plus(imgui.Vec2{X: 4, Y: 4}), bg, 3, CornerFlags_All)
+		for _, e := range legend {
+			col, labelColor := e.color, textColor
+			if s.IsHidden(e.series.Label) {
+				col.A /= 4
+				labelColor.A /= 3
+			}
+			box := imgui.Vec2{X: lineHeight * 0.7, Y: lineHeight * 0.7}
+			boxMin := e.min.Plus(imgui.Vec2{X: 0, Y: (lineHeight - box.Y) / 2})
+			canvas.AddRectFilledF(boxMin, boxMin.Plus(box), col, 0, CornerFlags_None)
+			canvas.AddTextF(e.min.Plus(imgui.Vec2{X: lineHeight, Y: 0}), labelColor, e.series.Label)
+		}
+	}
+}
+
+func (p *PlotWidget) seriesColor(i int) color.RGBA {
+	if c := p.series[i].Color; c.A > 0 {
+		return c
+	}
+	return plotPalette[i%len(plotPalette)]
+}
+
+func (p *PlotWidget) layoutLegend(plotMin imgui.Vec2, lineHeight float32) []plotLegendEntry {
+	if !p.showLegend {
+		return nil
+	}
+
+	var entries []plotLegendEntry
+	y := plotMin.Y + 8
+	for i, series := range p.series {
+		if series.Label == "" {
+			continue
+		}
+		w, _ := CalcTextSize(series.Label)
+		min := imgui.Vec2{X: plotMin.X + 8, Y: y}
+		entries = append(entries, plotLegendEntry{
+			series: series,
+			color:  p.seriesColor(i),
+			min:    min,
+			max:    min.Plus(imgui.Vec2{X: lineHeight + w, Y: lineHeight}),
+		})
+		y += lineHeight
+	}
+	return entries
+}
+
+// fit sets the range to the bounds of the visible series, with a margin.
+func (p *PlotWidget) fit() {
+	s := p.state
+	xMin, xMax := math.Inf(1), math.Inf(-1)
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	include := func(x, y float64) {
+		if tx := p.xScale.forward(x); !math.IsNaN(tx) && !math.IsInf(tx, 0) {
+			xMin, xMax = math.Min(xMin, tx), math.Max(xMax, tx)
+		}
+		if ty := p.yScale.forward(y); !math.IsNaN(ty) && !math.IsInf(ty, 0) {
+			yMin, yMax = math.Min(yMin, ty), math.Max(yMax, ty)
+		}
+	}
+
+	for _, series := range p.series {
+		if s.IsHidden(series.Label) {
+			continue
+		}
+		half := 0.0
+		if series.Type == PlotSeriesBars {
+			half = series.barWidth() / 2
+		}
+		for i := 0; i < series.len(); i++ {
+			x := series.x(i)
+			include(x-half, series.Y[i])
+			include(x+half, series.Y[i])
+			if (series.Type == PlotSeriesBars || series.Type == PlotSeriesShaded) && p.yScale == PlotAxisLinear {
+				include(x, 0)
+			}
+		}
+	}
+
+	if math.IsInf(xMin, 1) {
+		xMin, xMax = 0, 1
+	}
+	if math.IsInf(yMin, 1) {
+		yMin, yMax = 0, 1
+	}
+	if xMin == xMax {
+		xMin, xMax = xMin-0.5, xMax+0.5
+	}
+	if yMin == yMax {
+		yMin, yMax = yMin-0.5, yMax+0.5
+	}
+
+	xPad, yPad := (xMax-xMin)*0.02, (yMax-yMin)*0.05
+	if !p.fixedX {
+		s.xMin, s.xMax = xMin-xPad, xMax+xPad
+	}
+	s.yMin, s.yMax = yMin-yPad, yMax+yPad
+}
+
+// visibleIndices returns the indices of the points of series within the x range of the plot, split into columns.
+// Points outside are left out, except the ones lines enter or leave the plot from. Like PlotRingBuffer.window,
+// the points of a column are reduced to the ones with the minimum and the maximum y.
+func (p *PlotWidget) visibleIndices(series *PlotSeries, columns int) []int {
+	s := p.state
+	span := s.xMax - s.xMin
+	if !(span > 0) {
+		return nil
+	}
+	half := 0.0
+	if series.Type == PlotSeriesBars {
+		half = series.barWidth() / 2
+	}
+	lines := series.Type != PlotSeriesScatter && series.Type != PlotSeriesBars
+
+	// side is -1 left of the plot, 0 within, 1 right of it and 2 for points which can't be drawn.
+	side := func(i int) int {
+		lo, hi := p.xScale.forward(series.x(i)-half), p.xScale.forward(series.x(i)+half)
+		y := p.yScale.forward(series.Y[i])
+		switch {
+		case math.IsNaN(lo) || math.IsNaN(hi) || math.IsNaN(y) || math.IsInf(lo, 0) || math.IsInf(hi, 0) || math.IsInf(y, 0):
+			return 2
+		case hi < s.xMin:
+			return -1
+		case lo > s.xMax:
+			return 1
+		}
+		return 0
+	}
+
+	var indices []int
+	column := -1
+	minI, maxI := -1, -1
+	var yMin, yMax float64
+	flush := func() {
+		if minI < 0 {
+			return
+		}
+		first, second := minI, maxI
+		if first > second {
+			first, second = second, first
+		}
+		indices = append(indices, first)
+		if second != first {
+			indices = append(indices, second)
+		}
+		minI, maxI = -1, -1
+	}
+
+	n := series.len()
+	prev, cur := 2, 2
+	if n > 0 {
+		prev, cur = side(0), side(0)
+	}
+	for i := 0; i < n; i++ {
+		next := cur
+		if i+1 < n {
+			next = side(i + 1)
+		}
+		switch {
+		case cur == 0:
+			k := int(math.Max(0, math.Min(float64(columns-1), (p.xScale.forward(series.x(i))-s.xMin)/span*float64(columns))))
+			if k != column {
+				flush()
+				column = k
+			}
+			y := p.yScale.forward(series.Y[i])
+			if minI < 0 || y < yMin {
+				minI, yMin = i, y
+			}
+			if maxI < 0 || y > yMax {
+				maxI, yMax = i, y
+			}
+		case !lines:
+			// Markers and bars outside of the plot connect to nothing.
+		case cur == 2 && prev != 2, cur != 2 && (prev != cur && prev != 2 || next != cur && next != 2):
+			// Gaps in lines, and the points lines enter or leave the plot from.
+			flush()
+			column = -1
+			indices = append(indices, i)
+		}
+		prev, cur = cur, next
+	}
+	flush()
+
+	return indices
+}
+
+func (p *PlotWidget) drawSeries(canvas *Canvas, series *PlotSeries, indices []int, col color.RGBA, toScreen func(x, y float64) imgui.Vec2, bottom float32) {
+	valid := func(v imgui.Vec2) bool {
+		return !math.IsNaN(float64(v.X)) && !math.IsNaN(float64(v.Y)) && !math.IsInf(float64(v.X), 0) && !math.IsInf(float64(v.Y), 0)
+	}
+
+	// Baseline of bars and shaded areas, the bottom of log axes.
+	baseline := bottom
+	if p.yScale == PlotAxisLinear {
+		baseline = toScreen(0, 0).Y
+	}
+
+	switch series.Type {
+	case PlotSeriesScatter:
+		for _, i := range indices {
+			if pt := toScreen(series.x(i), series.Y[i]); valid(pt) {
+				canvas.AddCircleFilledF(pt, series.MarkerSize, col, 8)
+			}
+		}
+	case PlotSeriesBars:
+		half := series.barWidth() / 2
+		for _, i := range indices {
+			x := series.x(i)
+			a, b := toScreen(x-half, series.Y[i]), toScreen(x+half, series.Y[i])
+			if valid(a) && valid(b) {
+				canvas.AddRectFilledF(imgui.Vec2{X: a.X, Y: minf(a.Y, baseline)}, imgui.Vec2{X: b.X, Y: maxf(a.Y, baseline)}, col, 0, CornerFlags_None)
+			}
+		}
+	default:
+		var run []imgui.Vec2
+		flush := func() {
+			if len(run) > 1 {
+				canvas.AddPolylineF(run, col, false, series.Thickness)
+			}
+			run = run[:0]
+		}
+		fill := col
+		fill.A /= 3
+
+		var prev imgui.Vec2
+		for _, i := range indices {
+			pt := toScreen(series.x(i), series.Y[i])
+			if !valid(pt) {
+				flush()
+				continue
+			}
+			if len(run) > 0 && series.Type == PlotSeriesStairs {
+				run = append(run, imgui.Vec2{X: pt.X, Y: prev.Y})
+			}
+			if len(run) > 0 && series.Type == PlotSeriesShaded {
+				plotShadeSegment(canvas, prev, pt, baseline, fill)
+			}
+			run = append(run, pt)
+			prev = pt
+		}
+		flush()
+	}
+}
+
+// plotShadeSegment fills between the line a-b and the baseline, split where it crosses it.
+func plotShadeSegment(canvas *Canvas, a, b imgui.Vec2, baseline float32, col color.RGBA) {
+	if (a.Y-baseline)*(b.Y-baseline) < 0 {
+		t := (baseline - a.Y) / (b.Y - a.Y)
+		cross := imgui.Vec2{X: a.X + (b.X-a.X)*t, Y: baseline}
+		canvas.AddTriangleFilledF(a, cross, imgui.Vec2{X: a.X, Y: baseline}, col)
+		canvas.AddTriangleFilledF(cross, b, imgui.Vec2{X: b.X, Y: baseline}, col)
+		return
+	}
+	canvas.AddQuadFilledF(a, b, imgui.Vec2{X: b.X, Y: baseline}, imgui.Vec2{X: a.X, Y: baseline}, col)
+}
+
+// plotTicks returns ticks between the transformed values min and max, about spacing pixels apart or more.
+func plotTicks(min, max float64, pixels, spacing float32, scale PlotAxisScale) []plotTick {
+	if !plotRangeValid(min, max) {
+		return nil
+	}
+	count := math.Max(1, float64(pixels/spacing))
+	step := niceStep((max - min) / count)
+	if scale == PlotAxisLog {
+		step = math.Max(1, math.Ceil(step))
+	}
+	if step <= 0 || math.IsNaN(step) || math.IsInf(step, 0) {
+		return nil
+	}
+
+	decimals := int(math.Max(0, -math.Floor(math.Log10(step))))
+	var ticks []plotTick
+	for v := math.Ceil(min/step) * step; v <= max+step*1e-9; v += step {
+		if math.Abs(v) < step*1e-9 {
+			v = 0
+		}
+		label := strconv.FormatFloat(v, 'f', decimals, 64)
+		if scale == PlotAxisLog {
+			label = strconv.FormatFloat(math.Pow(10, v), 'g', -1, 64)
+		} else if v != 0 && (math.Abs(v) >= 1e6 || math.Abs(v) < 1e-4) || v == 0 && step < 1e-4 {
+			// Zero matches the exponent labels around it instead of showing all decimals.
+			label = strconv.FormatFloat(v, 'g', 4, 64)
+		}
+		ticks = append(ticks, plotTick{pos: v, label: label})
+		if len(ticks) > 1000 {
+			break
+		}
+	}
+	return ticks
+}
+
+// plotRangeValid reports whether min..max can be shown: finite, and not collapsed to the precision of float64.
+func plotRangeValid(min, max float64) bool {
+	d := max - min
+	return d > (math.Abs(min)+math.Abs(max))*1e-12 && !math.IsInf(d, 0)
+}
+
+// plotZoom scales min..max around anchor by factor, the range is kept when it would become invalid.
+func plotZoom(min, max, anchor, factor float64) (newMin, newMax float64) {
+	newMin, newMax = anchor-(anchor-min)*factor, anchor+(max-anchor)*factor
+	if !plotRangeValid(newMin, newMax) {
+		return min, max
+	}
+	return newMin, newMax
+}
+
+// niceStep rounds step up to 1, 2 or 5 times a power of ten.
+func niceStep(step float64) float64 {
+	if step <= 0 {
+		return 0
+	}
+	exp := math.Pow(10, math.Floor(math.Log10(step)))
+	f := step / exp
+	switch {
+	case f <= 1:
+		return exp
+	case f <= 2:
+		return 2 * exp
+	case f <= 5:
+		return 5 * exp
+	default:
+		return 10 * exp
+	}
+}
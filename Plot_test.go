@@ -0,0 +1,250 @@
+package giu
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNiceStep(t *testing.T) {
+	tt := []struct {
+		step, want float64
+	}{
+		{step: 0, want: 0},
+		{step: -1, want: 0},
+		{step: 1, want: 1},
+		{step: 1.2, want: 2},
+		{step: 3, want: 5},
+		{step: 7, want: 10},
+		{step: 0.03, want: 0.05},
+		{step: 1500, want: 2000},
+	}
+	for _, tc := range tt {
+		assert.InDelta(t, tc.want, niceStep(tc.step), tc.want*1e-12, "step %v", tc.step)
+	}
+}
+
+func tickLabels(ticks []plotTick) []string {
+	var labels []string
+	for _, t := range ticks {
+		labels = append(labels, t.label)
+	}
+	return labels
+}
+
+func TestPlotTicks(t *testing.T) {
+	tt := []struct {
+		name     string
+		min, max float64
+		pixels   float32
+		scale    PlotAxisScale
+		labels   []string
+	}{
+		{name: "linear", min: 0, max: 10, pixels: 200, labels: []string{"0", "5", "10"}},
+		{name: "negative", min: -1.1, max: 1.1, pixels: 400, labels: []string{"-1.0", "-0.5", "0.0", "0.5", "1.0"}},
+		{name: "offset", min: 0.5, max: 3.5, pixels: 240, labels: []string{"1", "2", "3"}},
+		{name: "large values", min: 0, max: 4e6, pixels: 160, labels: []string{"0", "2e+06", "4e+06"}},
+		{name: "small values", min: 0, max: 2e-5, pixels: 160, labels: []string{"0", "1e-05", "2e-05"}},
+		{name: "log", min: 0, max: 3, pixels: 240, scale: PlotAxisLog, labels: []string{"1", "10", "100", "1000"}},
+		{name: "log below one", min: -2, max: 0, pixels: 240, scale: PlotAxisLog, labels: []string{"0.01", "0.1", "1"}},
+		{name: "log with many decades", min: 0, max: 10, pixels: 80, scale: PlotAxisLog, labels: []string{"1", "1e+10"}},
+		{name: "log steps of whole decades", min: 0, max: 0.5, pixels: 400, scale: PlotAxisLog, labels: []string{"1"}},
+		{name: "narrow pixels", min: 0, max: 10, pixels: 10, labels: []string{"0", "10"}},
+		{name: "empty range", min: 1, max: 1, pixels: 200},
+		{name: "inverted range", min: 1, max: 0, pixels: 200},
+		{name: "collapsed by zoom", min: 1e9, max: 1e9 + 1e-7, pixels: 200},
+		{name: "infinite", min: math.Inf(-1), max: 0, pixels: 200},
+		{name: "not a number", min: math.NaN(), max: 1, pixels: 200},
+		{name: "no pixels", min: 0, max: 1, pixels: 0, labels: []string{"0", "1"}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			ticks := plotTicks(td.min, td.max, td.pixels, 80, td.scale)
+			assert.Equal(t, td.labels, tickLabels(ticks))
+			for _, tick := range ticks {
+				assert.True(t, tick.pos >= td.min && tick.pos <= td.max+1e-9, "tick %v out of range", tick.pos)
+			}
+		})
+	}
+}
+
+func TestPlotZoom(t *testing.T) {
+	min, max := plotZoom(0, 10, 5, 0.5)
+	assert.Equal(t, []float64{2.5, 7.5}, []float64{min, max})
+
+	min, max = plotZoom(0, 10, 0, 2)
+	assert.Equal(t, []float64{0, 20}, []float64{min, max})
+
+	// Zooming in stops before the range collapses, zooming out before it overflows.
+	min, max = 1e9, 1e9+1
+	for i := 0; i < 1000; i++ {
+		min, max = plotZoom(min, max, 1e9+0.5, 1/plotZoomStep)
+	}
+	assert.True(t, plotRangeValid(min, max))
+	assert.Less(t, max-min, 1e-2)
+
+	min, max = -1, 1
+	for i := 0; i < 10000; i++ {
+		min, max = plotZoom(min, max, 0, plotZoomStep)
+	}
+	assert.True(t, plotRangeValid(min, max))
+}
+
+func TestPlotFit(t *testing.T) {
+	tt := []struct {
+		name                   string
+		yScale                 PlotAxisScale
+		series                 []*PlotSeries
+		hidden                 string
+		xMin, xMax, yMin, yMax float64
+	}{
+		{name: "empty", xMin: -0.02, xMax: 1.02, yMin: -0.05, yMax: 1.05},
+		{
+			name:   "line",
+			series: []*PlotSeries{PlotLine("a", nil, []float64{1, 2, 3})},
+			xMin:   -0.04, xMax: 2.04, yMin: 0.9, yMax: 3.1,
+		},
+		{
+			name:   "single point",
+			series: []*PlotSeries{PlotScatter("a", []float64{5}, []float64{5})},
+			xMin:   4.48, xMax: 5.52, yMin: 4.45, yMax: 5.55,
+		},
+		{
+			name:   "bars from zero",
+			series: []*PlotSeries{PlotBars("a", []float64{0, 3}, []float64{2, 4})},
+			xMin:   -1.1, xMax: 4.1, yMin: -0.2, yMax: 4.2,
+		},
+		{
+			name:   "hidden series",
+			series: []*PlotSeries{PlotLine("a", nil, []float64{1, 2, 3}), PlotLine("b", nil, []float64{100, 200})},
+			hidden: "b",
+			xMin:   -0.04, xMax: 2.04, yMin: 0.9, yMax: 3.1,
+		},
+		{
+			name:   "log without values <= 0",
+			yScale: PlotAxisLog,
+			series: []*PlotSeries{PlotLine("a", nil, []float64{-1, 0, 10, 1000})},
+			xMin:   -0.06, xMax: 3.06, yMin: 0.9, yMax: 3.1,
+		},
+		{
+			name:   "log bars not from zero",
+			yScale: PlotAxisLog,
+			series: []*PlotSeries{PlotBars("a", []float64{0}, []float64{100})},
+			xMin:   -0.3467, xMax: 0.3467, yMin: 1.45, yMax: 2.55,
+		},
+		{
+			name:   "log with only values <= 0",
+			yScale: PlotAxisLog,
+			series: []*PlotSeries{PlotLine("a", nil, []float64{-1, 0})},
+			xMin:   -0.02, xMax: 1.02, yMin: -0.05, yMax: 1.05,
+		},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			state := &PlotState{}
+			state.SetHidden(td.hidden, true)
+			PlotV("plot", state, 100, 100, "", "", "", PlotAxisLinear, td.yScale, true, td.series...).fit()
+
+			assert.InDelta(t, td.xMin, state.xMin, 1e-3, "xMin")
+			assert.InDelta(t, td.xMax, state.xMax, 1e-3, "xMax")
+			assert.InDelta(t, td.yMin, state.yMin, 1e-3, "yMin")
+			assert.InDelta(t, td.yMax, state.yMax, 1e-3, "yMax")
+			assert.True(t, plotRangeValid(state.xMin, state.xMax))
+			assert.True(t, plotRangeValid(state.yMin, state.yMax))
+		})
+	}
+}
+
+func TestPlotFitKeepsFixedX(t *testing.T) {
+	state := &PlotState{xMin: -10, xMax: 0}
+	plot := Plot("plot", state, 100, 100, PlotLine("a", []float64{5, 6}, []float64{1, 2}))
+	plot.fixedX = true
+	plot.fit()
+	assert.Equal(t, []float64{-10, 0}, []float64{state.xMin, state.xMax})
+	assert.InDelta(t, 0.95, state.yMin, 1e-9)
+}
+
+func TestPlotVisibleIndices(t *testing.T) {
+	many := make([]float64, 100000)
+	for i := range many {
+		many[i] = float64(i % 7)
+	}
+	nan := math.NaN()
+
+	tt := []struct {
+		name       string
+		xScale     PlotAxisScale
+		series     *PlotSeries
+		xMin, xMax float64
+		columns    int
+		want       []int
+	}{
+		{
+			name:   "all visible",
+			series: PlotLine("a", nil, []float64{1, 2, 3}),
+			xMin:   -1, xMax: 3, columns: 100,
+			want: []int{0, 1, 2},
+		},
+		{
+			// The points before and after the range are kept, lines leave the plot through its edges.
+			name:   "clipped",
+			series: PlotLine("a", nil, []float64{0, 1, 2, 3, 4, 5, 6}),
+			xMin:   2.5, xMax: 4.5, columns: 100,
+			want: []int{2, 3, 4, 5},
+		},
+		{
+			name:   "line crossing the plot",
+			series: PlotLine("a", []float64{-10, 10, 20}, []float64{0, 1, 2}),
+			xMin:   0, xMax: 1, columns: 100,
+			want: []int{0, 1},
+		},
+		{
+			// Minimum and maximum of each column, in the order of the series.
+			name:   "decimated",
+			series: PlotLine("a", []float64{0, 0.1, 0.2, 0.3, 1.1, 1.2, 1.3}, []float64{5, 1, 9, 3, 2, 8, 0}),
+			xMin:   0, xMax: 2, columns: 2,
+			want: []int{1, 2, 5, 6},
+		},
+		{
+			name:   "gaps",
+			series: PlotLine("a", nil, []float64{1, nan, nan, 2, math.Inf(1), 3}),
+			xMin:   0, xMax: 5, columns: 100,
+			want: []int{0, 1, 3, 4, 5},
+		},
+		{
+			name:   "bars partly visible",
+			series: PlotBars("a", []float64{0, 3, 6}, []float64{1, 2, 3}),
+			xMin:   3.5, xMax: 5.5, columns: 100,
+			want: []int{1, 2},
+		},
+		{
+			name:   "log axis without values <= 0",
+			xScale: PlotAxisLog,
+			series: PlotScatter("a", []float64{-1, 0, 10, 100}, []float64{1, 2, 3, 4}),
+			xMin:   0.5, xMax: 2.5, columns: 100,
+			want: []int{2, 3},
+		},
+		{
+			name:   "many points",
+			series: PlotLine("a", nil, many),
+			xMin:   -1, xMax: float64(len(many)), columns: 1000,
+		},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			state := &PlotState{xMin: td.xMin, xMax: td.xMax, yMin: 0, yMax: 10}
+			plot := PlotV("plot", state, 100, 100, "", "", "", td.xScale, PlotAxisLinear, true, td.series)
+			indices := plot.visibleIndices(td.series, td.columns)
+			if td.want != nil {
+				assert.Equal(t, td.want, indices)
+				return
+			}
+			assert.LessOrEqual(t, len(indices), 2*td.columns)
+			assert.Greater(t, len(indices), td.columns)
+		})
+	}
+}
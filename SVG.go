@@ -0,0 +1,1293 @@
+package giu
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// SVG is a parsed SVG document, which can be drawn onto a Canvas or rasterized into a texture.
+//
+// Only a subset of SVG is supported: path (M/L/H/V/C/S/Q/T/A/Z commands), rect, circle, ellipse,
+// line, polyline and polygon elements within groups, with solid fills, strokes, opacities and transforms.
+// Gradients, text, clipping, masks and references are ignored.
+type SVG struct {
+	width  float64
+	height float64
+	shapes []svgShape
+
+	// Triangles of the fills, for the scales recently drawn onto a Canvas.
+	fillCache map[float64][][]imgui.Vec2
+}
+
+// svgFillCacheSize is the count of scales whose triangulated fills are kept.
+const svgFillCacheSize = 4
+
+type svgPoint struct {
+	X, Y float64
+}
+
+func (p svgPoint) add(q svgPoint) svgPoint  { return svgPoint{p.X + q.X, p.Y + q.Y} }
+func (p svgPoint) sub(q svgPoint) svgPoint  { return svgPoint{p.X - q.X, p.Y - q.Y} }
+func (p svgPoint) mul(f float64) svgPoint   { return svgPoint{p.X * f, p.Y * f} }
+func (p svgPoint) cross(q svgPoint) float64 { return p.X*q.Y - p.Y*q.X }
+func (p svgPoint) dist(q svgPoint) float64  { return math.Hypot(p.X-q.X, p.Y-q.Y) }
+func (p svgPoint) vec2(scale float64) imgui.Vec2 {
+	return imgui.Vec2{X: float32(p.X * scale), Y: float32(p.Y * scale)}
+}
+
+// svgSegment is a line, or a cubic bezier curve through the control points c1 and c2.
+type svgSegment struct {
+	cubic  bool
+	c1, c2 svgPoint
+	to     svgPoint
+}
+
+type svgSubpath struct {
+	start    svgPoint
+	segments []svgSegment
+	closed   bool
+}
+
+type svgShape struct {
+	subpaths    []svgSubpath
+	fill        color.RGBA
+	hasFill     bool
+	evenOdd     bool
+	stroke      color.RGBA
+	hasStroke   bool
+	strokeWidth float64
+}
+
+// svgMatrix is an affine transform, x' = a*x + c*y + e and y' = b*x + d*y + f.
+type svgMatrix [6]float64
+
+var svgIdentity = svgMatrix{1, 0, 0, 1, 0, 0}
+
+func (m svgMatrix) mul(n svgMatrix) svgMatrix {
+	return svgMatrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m svgMatrix) apply(p svgPoint) svgPoint {
+	return svgPoint{m[0]*p.X + m[2]*p.Y + m[4], m[1]*p.X + m[3]*p.Y + m[5]}
+}
+
+// scale returns the average factor lengths are scaled by, for stroke widths.
+func (m svgMatrix) scale() float64 {
+	return math.Sqrt(math.Abs(m[0]*m[3] - m[1]*m[2]))
+}
+
+type svgPaint struct {
+	color color.RGBA
+	none  bool
+}
+
+type svgStyle struct {
+	fill          svgPaint
+	stroke        svgPaint
+	strokeWidth   float64
+	opacity       float64
+	fillOpacity   float64
+	strokeOpacity float64
+	evenOdd       bool
+	transform     svgMatrix
+}
+
+// ParseSVG parses an SVG document.
+func ParseSVG(data []byte) (*SVG, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	svg := &SVG{}
+	var styles []svgStyle
+	root := true
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			attrs := svgAttributes(t.Attr)
+			if root {
+				if t.Name.Local != "svg" {
+					continue
+				}
+				root = false
+				style := svgStyle{
+					fill:          svgPaint{color: color.RGBA{0, 0, 0, 255}},
+					stroke:        svgPaint{none: true},
+					strokeWidth:   1,
+					opacity:       1,
+					fillOpacity:   1,
+					strokeOpacity: 1,
+				}
+				delete(attrs, "transform")
+				style, err := style.inherit(attrs)
+				if err != nil {
+					return nil, err
+				}
+				style.transform = svg.parseViewport(attrs)
+				styles = append(styles, style)
+				continue
+			}
+
+			style, err := styles[len(styles)-1].inherit(attrs)
+			if err != nil {
+				return nil, err
+			}
+
+			switch t.Name.Local {
+			case "g", "svg", "a":
+				styles = append(styles, style)
+				continue
+			case "path", "rect", "circle", "ellipse", "line", "polyline", "polygon":
+				shape, err := parseSVGShape(t.Name.Local, attrs, style)
+				if err != nil {
+					return nil, err
+				}
+				if shape.hasFill || shape.hasStroke {
+					svg.shapes = append(svg.shapes, shape)
+				}
+			}
+
+			// Shapes have no children worth drawing, unknown elements like defs are skipped.
+			if err := decoder.Skip(); err != nil {
+				return nil, err
+			}
+		case xml.EndElement:
+			if root {
+				continue
+			}
+			styles = styles[:len(styles)-1]
+			if len(styles) == 0 {
+				return svg, nil
+			}
+		}
+	}
+
+	if root {
+		return nil, errors.New("no svg element found")
+	}
+	return svg, nil
+}
+
+// Size returns the size of the document, in pixels at scale 1.
+func (s *SVG) Size() (width, height float32) {
+	return float32(s.width), float32(s.height)
+}
+
+// parseViewport reads the size of the document and returns the transform from the view box to it.
+func (s *SVG) parseViewport(attrs map[string]string) svgMatrix {
+	s.width, _ = parseSVGLength(attrs["width"])
+	s.height, _ = parseSVGLength(attrs["height"])
+
+	viewBox, err := parseSVGNumbers(attrs["viewBox"])
+	if err != nil || len(viewBox) != 4 || viewBox[2] <= 0 || viewBox[3] <= 0 {
+		return svgIdentity
+	}
+
+	if s.width <= 0 {
+		s.width = viewBox[2]
+	}
+	if s.height <= 0 {
+		s.height = viewBox[3]
+	}
+
+	// Like preserveAspectRatio="xMidYMid meet", the default.
+	scale := math.Min(s.width/viewBox[2], s.height/viewBox[3])
+	offsetX := (s.width-viewBox[2]*scale)/2 - viewBox[0]*scale
+	offsetY := (s.height-viewBox[3]*scale)/2 - viewBox[1]*scale
+	return svgMatrix{scale, 0, 0, scale, offsetX, offsetY}
+}
+
+// svgAttributes maps attribute names to values, declarations in a style attribute take precedence.
+func svgAttributes(attrs []xml.Attr) map[string]string {
+	result := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		result[a.Name.Local] = strings.TrimSpace(a.Value)
+	}
+
+	for _, decl := range strings.Split(result["style"], ";") {
+		if kv := strings.SplitN(decl, ":", 2); len(kv) == 2 {
+			result[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	return result
+}
+
+func (parent svgStyle) inherit(attrs map[string]string) (svgStyle, error) {
+	style := parent
+	var err error
+
+	if v, ok := attrs["fill"]; ok {
+		style.fill = parseSVGPaint(v, parent.fill)
+	}
+	if v, ok := attrs["stroke"]; ok {
+		style.stroke = parseSVGPaint(v, parent.stroke)
+	}
+	if v, ok := attrs["stroke-width"]; ok {
+		if style.strokeWidth, err = parseSVGLength(v); err != nil {
+			return style, err
+		}
+	}
+	if v, ok := attrs["fill-rule"]; ok {
+		style.evenOdd = v == "evenodd"
+	}
+
+	for name, dst := range map[string]*float64{"opacity": &style.opacity, "fill-opacity": &style.fillOpacity, "stroke-opacity": &style.strokeOpacity} {
+		if v, ok := attrs[name]; ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return style, fmt.Errorf("invalid %s %q", name, v)
+			}
+			*dst = math.Max(0, math.Min(1, f))
+			if name == "opacity" {
+				*dst *= parent.opacity
+			}
+		}
+	}
+
+	if v, ok := attrs["transform"]; ok {
+		m, err := parseSVGTransform(v)
+		if err != nil {
+			return style, err
+		}
+		style.transform = parent.transform.mul(m)
+	}
+
+	return style, nil
+}
+
+func parseSVGShape(element string, attrs map[string]string, style svgStyle) (svgShape, error) {
+	num := func(name string) float64 {
+		v, _ := parseSVGLength(attrs[name])
+		return v
+	}
+
+	var b svgPathBuilder
+	switch element {
+	case "path":
+		if err := b.parse(attrs["d"]); err != nil {
+			return svgShape{}, err
+		}
+	case "rect":
+		x, y, w, h := num("x"), num("y"), num("width"), num("height")
+		rx, hasRx := attrs["rx"]
+		ry, hasRy := attrs["ry"]
+		radiusX, _ := parseSVGLength(rx)
+		radiusY, _ := parseSVGLength(ry)
+		if !hasRx {
+			radiusX = radiusY
+		}
+		if !hasRy {
+			radiusY = radiusX
+		}
+		b.rect(x, y, w, h, math.Min(radiusX, w/2), math.Min(radiusY, h/2))
+	case "circle":
+		b.ellipse(num("cx"), num("cy"), num("r"), num("r"))
+	case "ellipse":
+		b.ellipse(num("cx"), num("cy"), num("rx"), num("ry"))
+	case "line":
+		b.moveTo(svgPoint{num("x1"), num("y1")})
+		b.lineTo(svgPoint{num("x2"), num("y2")})
+		// A line has nothing to fill.
+		style.fill.none = true
+	case "polyline", "polygon":
+		values, err := parseSVGNumbers(attrs["points"])
+		if err != nil {
+			return svgShape{}, err
+		}
+		for i := 0; i+1 < len(values); i += 2 {
+			p := svgPoint{values[i], values[i+1]}
+			if i == 0 {
+				b.moveTo(p)
+			} else {
+				b.lineTo(p)
+			}
+		}
+		if element == "polygon" {
+			b.close()
+		}
+	}
+
+	shape := svgShape{
+		subpaths:    transformSubpaths(b.subpaths, style.transform),
+		evenOdd:     style.evenOdd,
+		strokeWidth: style.strokeWidth * style.transform.scale(),
+	}
+	if !style.fill.none {
+		shape.fill = svgWithAlpha(style.fill.color, style.opacity*style.fillOpacity)
+		shape.hasFill = shape.fill.A > 0
+	}
+	if !style.stroke.none && shape.strokeWidth > 0 {
+		shape.stroke = svgWithAlpha(style.stroke.color, style.opacity*style.strokeOpacity)
+		shape.hasStroke = shape.stroke.A > 0
+	}
+	return shape, nil
+}
+
+func transformSubpaths(subpaths []svgSubpath, m svgMatrix) []svgSubpath {
+	// Bezier curves are invariant under affine transforms, transforming their control points is enough.
+	for i := range subpaths {
+		sp := &subpaths[i]
+		sp.start = m.apply(sp.start)
+		for j := range sp.segments {
+			seg := &sp.segments[j]
+			seg.c1 = m.apply(seg.c1)
+			seg.c2 = m.apply(seg.c2)
+			seg.to = m.apply(seg.to)
+		}
+	}
+	return subpaths
+}
+
+func svgWithAlpha(c color.RGBA, alpha float64) color.RGBA {
+	c.A = uint8(math.Round(float64(c.A) * alpha))
+	return c
+}
+
+// parseSVGPaint parses a fill or stroke. Like in browsers, values that can't be parsed keep the inherited paint.
+func parseSVGPaint(value string, parent svgPaint) svgPaint {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case value == "none" || strings.HasPrefix(value, "url("):
+		// Gradients and patterns are not supported.
+		return svgPaint{none: true}
+	case value == "transparent":
+		return svgPaint{}
+	}
+
+	if c, ok := parseSVGColor(value); ok {
+		return svgPaint{color: c}
+	}
+	// inherit, currentColor and unknown values.
+	return parent
+}
+
+// parseSVGColor parses a CSS color: a keyword, #rgb, #rgba, #rrggbb, #rrggbbaa, rgb(), rgba(), hsl() or hsla().
+func parseSVGColor(value string) (color.RGBA, bool) {
+	if c, ok := svgNamedColors[value]; ok {
+		return c, true
+	}
+	if strings.HasPrefix(value, "#") {
+		return parseSVGHexColor(value[1:])
+	}
+
+	open := strings.IndexByte(value, '(')
+	if open < 0 || !strings.HasSuffix(value, ")") {
+		return color.RGBA{}, false
+	}
+	// Arguments are separated by commas, or by spaces with the alpha after a slash.
+	args := strings.FieldsFunc(value[open+1:len(value)-1], func(r rune) bool {
+		return r == ',' || r == '/' || r == ' ' || r == '\t'
+	})
+	if len(args) != 3 && len(args) != 4 {
+		return color.RGBA{}, false
+	}
+
+	alpha := 1.0
+	if len(args) == 4 {
+		var ok bool
+		if alpha, ok = parseSVGColorComponent(args[3], 1); !ok {
+			return color.RGBA{}, false
+		}
+	}
+
+	var rgb [3]float64
+	switch strings.TrimSpace(value[:open]) {
+	case "rgb", "rgba":
+		for i := range rgb {
+			v, ok := parseSVGColorComponent(args[i], 255)
+			if !ok {
+				return color.RGBA{}, false
+			}
+			rgb[i] = v / 255
+		}
+	case "hsl", "hsla":
+		hue, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+		saturation, okS := parseSVGColorComponent(args[1], 100)
+		lightness, okL := parseSVGColorComponent(args[2], 100)
+		if err != nil || !okS || !okL {
+			return color.RGBA{}, false
+		}
+		rgb = hslToRGB(hue/360, saturation/100, lightness/100)
+	default:
+		return color.RGBA{}, false
+	}
+
+	channel := func(f float64) uint8 { return uint8(math.Round(f * 255)) }
+	return color.RGBA{channel(rgb[0]), channel(rgb[1]), channel(rgb[2]), channel(alpha)}, true
+}
+
+func parseSVGHexColor(hex string) (color.RGBA, bool) {
+	if len(hex) == 3 || len(hex) == 4 {
+		long := make([]byte, 0, 8)
+		for i := 0; i < len(hex); i++ {
+			long = append(long, hex[i], hex[i])
+		}
+		hex = string(long)
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.RGBA{}, false
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)}, true
+}
+
+// parseSVGColorComponent parses a number or a percentage of max, clamped to 0..max.
+func parseSVGColorComponent(value string, max float64) (float64, bool) {
+	percent := strings.HasSuffix(value, "%")
+	f, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 0, false
+	}
+	if percent {
+		// Multiplied first, so 50% of 255 is exactly 127.5 and rounds up.
+		f = f * max / 100
+	}
+	return math.Max(0, math.Min(max, f)), true
+}
+
+// hslToRGB converts hue, saturation and lightness from 0 to 1 into red, green and blue from 0 to 1.
+func hslToRGB(h, s, l float64) [3]float64 {
+	q := l + s - l*s
+	if l < 0.5 {
+		q = l * (1 + s)
+	}
+	p := 2*l - q
+	channel := func(t float64) float64 {
+		t -= math.Floor(t)
+		switch {
+		case t < 1.0/6:
+			return p + (q-p)*6*t
+		case t < 1.0/2:
+			return q
+		case t < 2.0/3:
+			return p + (q-p)*(2.0/3-t)*6
+		default:
+			return p
+		}
+	}
+	return [3]float64{channel(h + 1.0/3), channel(h), channel(h - 1.0/3)}
+}
+
+// parseSVGLength parses a length in user units, units like px are ignored.
+func parseSVGLength(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.HasSuffix(value, "%") {
+		return 0, nil
+	}
+	value = strings.TrimRightFunc(value, func(r rune) bool { return r >= 'a' && r <= 'z' })
+	return strconv.ParseFloat(value, 64)
+}
+
+func parseSVGNumbers(value string) ([]float64, error) {
+	scanner := svgScanner{s: value}
+	var result []float64
+	for scanner.hasNumber() {
+		f, err := scanner.number()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func parseSVGTransform(value string) (svgMatrix, error) {
+	m := svgIdentity
+	rest := strings.TrimSpace(value)
+	for rest != "" {
+		open := strings.IndexByte(rest, '(')
+		end := strings.IndexByte(rest, ')')
+		if open < 0 || end < open {
+			return m, fmt.Errorf("invalid transform %q", value)
+		}
+
+		name := strings.Trim(rest[:open], " ,")
+		args, err := parseSVGNumbers(rest[open+1 : end])
+		if err != nil {
+			return m, err
+		}
+		rest = strings.TrimLeft(rest[end+1:], " ,")
+
+		arg := func(i int, def float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return def
+		}
+
+		var t svgMatrix
+		switch name {
+		case "matrix":
+			if len(args) != 6 {
+				return m, fmt.Errorf("invalid transform %q", value)
+			}
+			copy(t[:], args)
+		case "translate":
+			t = svgMatrix{1, 0, 0, 1, arg(0, 0), arg(1, 0)}
+		case "scale":
+			sx := arg(0, 1)
+			t = svgMatrix{sx, 0, 0, arg(1, sx), 0, 0}
+		case "rotate":
+			a := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			t = svgMatrix{1, 0, 0, 1, cx, cy}.
+				mul(svgMatrix{math.Cos(a), math.Sin(a), -math.Sin(a), math.Cos(a), 0, 0}).
+				mul(svgMatrix{1, 0, 0, 1, -cx, -cy})
+		case "skewX":
+			t = svgMatrix{1, 0, math.Tan(arg(0, 0) * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			t = svgMatrix{1, math.Tan(arg(0, 0) * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return m, fmt.Errorf("unsupported transform %q", name)
+		}
+		m = m.mul(t)
+	}
+	return m, nil
+}
+
+// svgScanner reads the compact number syntax of path data, e.g. "M10-5.5.5L1e2,0".
+type svgScanner struct {
+	s string
+	i int
+}
+
+func (sc *svgScanner) skipSeparators() {
+	for sc.i < len(sc.s) && strings.IndexByte(" \t\r\n,", sc.s[sc.i]) >= 0 {
+		sc.i++
+	}
+}
+
+func (sc *svgScanner) hasNumber() bool {
+	sc.skipSeparators()
+	return sc.i < len(sc.s) && strings.IndexByte("0123456789+-.", sc.s[sc.i]) >= 0
+}
+
+func (sc *svgScanner) number() (float64, error) {
+	sc.skipSeparators()
+	start := sc.i
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+		sc.i++
+	}
+	dot := false
+	for sc.i < len(sc.s) {
+		c := sc.s[sc.i]
+		if c == '.' && !dot {
+			dot = true
+		} else if c < '0' || c > '9' {
+			break
+		}
+		sc.i++
+	}
+	if sc.i < len(sc.s) && (sc.s[sc.i] == 'e' || sc.s[sc.i] == 'E') {
+		sc.i++
+		if sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+			sc.i++
+		}
+		for sc.i < len(sc.s) && sc.s[sc.i] >= '0' && sc.s[sc.i] <= '9' {
+			sc.i++
+		}
+	}
+
+	f, err := strconv.ParseFloat(sc.s[start:sc.i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number at %d in %q", start, sc.s)
+	}
+	return f, nil
+}
+
+// flag reads an arc flag, which may not be separated from the following number.
+func (sc *svgScanner) flag() (bool, error) {
+	sc.skipSeparators()
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '0' || sc.s[sc.i] == '1') {
+		sc.i++
+		return sc.s[sc.i-1] == '1', nil
+	}
+	return false, fmt.Errorf("invalid arc flag at %d in %q", sc.i, sc.s)
+}
+
+type svgPathBuilder struct {
+	subpaths []svgSubpath
+	current  svgPoint
+	lastCtrl svgPoint
+}
+
+func (b *svgPathBuilder) moveTo(p svgPoint) {
+	b.subpaths = append(b.subpaths, svgSubpath{start: p})
+	b.current = p
+	b.lastCtrl = p
+}
+
+func (b *svgPathBuilder) lineTo(p svgPoint) {
+	if len(b.subpaths) == 0 {
+		b.moveTo(b.current)
+	}
+	sp := &b.subpaths[len(b.subpaths)-1]
+	sp.segments = append(sp.segments, svgSegment{to: p})
+	b.current = p
+	b.lastCtrl = p
+}
+
+func (b *svgPathBuilder) cubicTo(c1, c2, p svgPoint) {
+	if len(b.subpaths) == 0 {
+		b.moveTo(b.current)
+	}
+	sp := &b.subpaths[len(b.subpaths)-1]
+	sp.segments = append(sp.segments, svgSegment{cubic: true, c1: c1, c2: c2, to: p})
+	b.current = p
+	b.lastCtrl = c2
+}
+
+func (b *svgPathBuilder) quadTo(c, p svgPoint) {
+	b.cubicTo(b.current.add(c.sub(b.current).mul(2.0/3)), p.add(c.sub(p).mul(2.0/3)), p)
+	b.lastCtrl = c
+}
+
+func (b *svgPathBuilder) close() {
+	if len(b.subpaths) == 0 {
+		return
+	}
+	sp := &b.subpaths[len(b.subpaths)-1]
+	sp.closed = true
+	b.current = sp.start
+	b.lastCtrl = sp.start
+	// Drawing after a close starts a new subpath at the same point.
+	b.subpaths = append(b.subpaths, svgSubpath{start: sp.start})
+}
+
+// arcTo adds an elliptical arc as cubic curves, see the SVG implementation notes on arcs.
+func (b *svgPathBuilder) arcTo(rx, ry, rotation float64, largeArc, sweep bool, p svgPoint) {
+	from := b.current
+	if from == p {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		b.lineTo(p)
+		return
+	}
+
+	phi := rotation * math.Pi / 180
+	sinPhi, cosPhi := math.Sincos(phi)
+	dx, dy := (from.X-p.X)/2, (from.Y-p.Y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(0, num/den))
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+	center := svgPoint{
+		cosPhi*cx1 - sinPhi*cy1 + (from.X+p.X)/2,
+		sinPhi*cx1 + cosPhi*cy1 + (from.Y+p.Y)/2,
+	}
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	point := func(a float64) svgPoint {
+		sin, cos := math.Sincos(a)
+		return svgPoint{center.X + rx*cos*cosPhi - ry*sin*sinPhi, center.Y + rx*cos*sinPhi + ry*sin*cosPhi}
+	}
+	tangent := func(a float64) svgPoint {
+		sin, cos := math.Sincos(a)
+		return svgPoint{-rx*sin*cosPhi - ry*cos*sinPhi, -rx*sin*sinPhi + ry*cos*cosPhi}
+	}
+
+	// Split into segments of at most 90 degrees, which cubic curves approximate closely.
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+	for i := 0; i < n; i++ {
+		a1 := theta + float64(i)*step
+		a2 := a1 + step
+		end := point(a2)
+		if i == n-1 {
+			end = p
+		}
+		b.cubicTo(point(a1).add(tangent(a1).mul(k)), point(a2).sub(tangent(a2).mul(k)), end)
+	}
+}
+
+func (b *svgPathBuilder) ellipse(cx, cy, rx, ry float64) {
+	if rx <= 0 || ry <= 0 {
+		return
+	}
+	b.moveTo(svgPoint{cx + rx, cy})
+	b.arcTo(rx, ry, 0, false, true, svgPoint{cx - rx, cy})
+	b.arcTo(rx, ry, 0, false, true, svgPoint{cx + rx, cy})
+	b.close()
+}
+
+func (b *svgPathBuilder) rect(x, y, w, h, rx, ry float64) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	if rx <= 0 || ry <= 0 {
+		b.moveTo(svgPoint{x, y})
+		b.lineTo(svgPoint{x + w, y})
+		b.lineTo(svgPoint{x + w, y + h})
+		b.lineTo(svgPoint{x, y + h})
+		b.close()
+		return
+	}
+	b.moveTo(svgPoint{x + rx, y})
+	b.lineTo(svgPoint{x + w - rx, y})
+	b.arcTo(rx, ry, 0, false, true, svgPoint{x + w, y + ry})
+	b.lineTo(svgPoint{x + w, y + h - ry})
+	b.arcTo(rx, ry, 0, false, true, svgPoint{x + w - rx, y + h})
+	b.lineTo(svgPoint{x + rx, y + h})
+	b.arcTo(rx, ry, 0, false, true, svgPoint{x, y + h - ry})
+	b.lineTo(svgPoint{x, y + ry})
+	b.arcTo(rx, ry, 0, false, true, svgPoint{x + rx, y})
+	b.close()
+}
+
+func (b *svgPathBuilder) parse(d string) error {
+	sc := svgScanner{s: d}
+	var cmd byte
+
+	for {
+		sc.skipSeparators()
+		if sc.i >= len(sc.s) {
+			return nil
+		}
+
+		if c := sc.s[sc.i]; (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			cmd = c
+			sc.i++
+		} else if cmd == 0 {
+			return fmt.Errorf("path data %q doesn't start with a command", d)
+		}
+		if len(b.subpaths) == 0 && cmd != 'M' && cmd != 'm' {
+			return fmt.Errorf("path data %q doesn't start with a moveto command", d)
+		}
+
+		relative := cmd >= 'a'
+		abs := func(x, y float64) svgPoint {
+			if relative {
+				return svgPoint{b.current.X + x, b.current.Y + y}
+			}
+			return svgPoint{x, y}
+		}
+
+		var nums [7]float64
+		read := func(n int) error {
+			for i := 0; i < n; i++ {
+				f, err := sc.number()
+				if err != nil {
+					return err
+				}
+				nums[i] = f
+			}
+			return nil
+		}
+
+		var err error
+		switch cmd {
+		case 'M', 'm':
+			if err = read(2); err == nil {
+				b.moveTo(abs(nums[0], nums[1]))
+				// Following pairs are implicit line commands.
+				if relative {
+					cmd = 'l'
+				} else {
+					cmd = 'L'
+				}
+			}
+		case 'L', 'l':
+			if err = read(2); err == nil {
+				b.lineTo(abs(nums[0], nums[1]))
+			}
+		case 'H', 'h':
+			if err = read(1); err == nil {
+				if relative {
+					b.lineTo(svgPoint{b.current.X + nums[0], b.current.Y})
+				} else {
+					b.lineTo(svgPoint{nums[0], b.current.Y})
+				}
+			}
+		case 'V', 'v':
+			if err = read(1); err == nil {
+				if relative {
+					b.lineTo(svgPoint{b.current.X, b.current.Y + nums[0]})
+				} else {
+					b.lineTo(svgPoint{b.current.X, nums[0]})
+				}
+			}
+		case 'C', 'c':
+			if err = read(6); err == nil {
+				b.cubicTo(abs(nums[0], nums[1]), abs(nums[2], nums[3]), abs(nums[4], nums[5]))
+			}
+		case 'S', 's':
+			if err = read(4); err == nil {
+				c1 := b.current.mul(2).sub(b.lastCtrl)
+				b.cubicTo(c1, abs(nums[0], nums[1]), abs(nums[2], nums[3]))
+			}
+		case 'Q', 'q':
+			if err = read(4); err == nil {
+				b.quadTo(abs(nums[0], nums[1]), abs(nums[2], nums[3]))
+			}
+		case 'T', 't':
+			if err = read(2); err == nil {
+				b.quadTo(b.current.mul(2).sub(b.lastCtrl), abs(nums[0], nums[1]))
+			}
+		case 'A', 'a':
+			var largeArc, sweep bool
+			if err = read(3); err == nil {
+				largeArc, err = sc.flag()
+			}
+			if err == nil {
+				sweep, err = sc.flag()
+			}
+			if err == nil {
+				nums[3], err = sc.number()
+			}
+			if err == nil {
+				nums[4], err = sc.number()
+			}
+			if err == nil {
+				b.arcTo(nums[0], nums[1], nums[2], largeArc, sweep, abs(nums[3], nums[4]))
+			}
+		case 'Z', 'z':
+			b.close()
+		default:
+			return fmt.Errorf("unsupported path command %q", cmd)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Smooth curves only reflect control points of the same kind of curve.
+		if strings.IndexByte("CcSsQqTt", cmd) < 0 {
+			b.lastCtrl = b.current
+		}
+	}
+}
+
+// flatten returns the points of sp, curves are split into lines about tolerance long at scale.
+func (sp svgSubpath) flatten(scale, tolerance float64) []svgPoint {
+	points := []svgPoint{sp.start}
+	from := sp.start
+	for _, seg := range sp.segments {
+		if seg.cubic {
+			length := from.dist(seg.c1) + seg.c1.dist(seg.c2) + seg.c2.dist(seg.to)
+			n := int(math.Max(1, math.Min(64, math.Ceil(length*scale/tolerance))))
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				mt := 1 - t
+				points = append(points, from.mul(mt*mt*mt).
+					add(seg.c1.mul(3*mt*mt*t)).
+					add(seg.c2.mul(3*mt*t*t)).
+					add(seg.to.mul(t*t*t)))
+			}
+		} else {
+			points = append(points, seg.to)
+		}
+		from = seg.to
+	}
+	return points
+}
+
+// AddSVG draws svg with its upper left corner at pos, scaled by scale.
+func (c *Canvas) AddSVG(svg *SVG, pos image.Point, scale float32) {
+	c.AddSVGF(svg, ToVec2(pos), scale)
+}
+
+// AddSVGF works like AddSVG with float coordinates.
+func (c *Canvas) AddSVGF(svg *SVG, pos imgui.Vec2, scale float32) {
+	if svg == nil {
+		return
+	}
+
+	fills := svg.triangulatedFills(float64(scale))
+	var points []imgui.Vec2
+
+	for i, shape := range svg.shapes {
+		if shape.hasFill && len(fills[i]) > 0 {
+			points = points[:0]
+			for _, p := range fills[i] {
+				points = append(points, pos.Plus(p))
+			}
+			c.drawlist.AddTriangles(points, ToVec4Color(shape.fill))
+		}
+
+		if shape.hasStroke {
+			for _, sp := range shape.subpaths {
+				if len(sp.segments) == 0 {
+					continue
+				}
+				c.drawlist.PathLineTo(pos.Plus(sp.start.vec2(float64(scale))))
+				for _, seg := range sp.segments {
+					if seg.cubic {
+						c.drawlist.PathBezierCurveTo(pos.Plus(seg.c1.vec2(float64(scale))), pos.Plus(seg.c2.vec2(float64(scale))), pos.Plus(seg.to.vec2(float64(scale))), 0)
+					} else {
+						c.drawlist.PathLineTo(pos.Plus(seg.to.vec2(float64(scale))))
+					}
+				}
+				c.drawlist.PathStroke(ToVec4Color(shape.stroke), sp.closed, float32(shape.strokeWidth)*scale)
+			}
+		}
+	}
+}
+
+// triangulatedFills returns the triangles covering the fill of each shape at scale, relative to the origin,
+// three points per triangle. Triangulations of the last few scales are cached.
+func (s *SVG) triangulatedFills(scale float64) [][]imgui.Vec2 {
+	if fills, ok := s.fillCache[scale]; ok {
+		return fills
+	}
+
+	fills := make([][]imgui.Vec2, len(s.shapes))
+	for i, shape := range s.shapes {
+		if !shape.hasFill {
+			continue
+		}
+
+		var polygons [][]svgPoint
+		for _, sp := range shape.subpaths {
+			if poly := cleanPolygon(sp.flatten(scale, 2)); len(poly) >= 3 {
+				polygons = append(polygons, poly)
+			}
+		}
+
+		if len(polygons) == 1 && isConvex(polygons[0]) {
+			// A fan covers convex polygons without ear clipping.
+			poly := polygons[0]
+			for j := 1; j+1 < len(poly); j++ {
+				fills[i] = append(fills[i], toScaledVec2s([]svgPoint{poly[0], poly[j], poly[j+1]}, scale)...)
+			}
+			continue
+		}
+
+		for _, poly := range groupPolygons(polygons, shape.evenOdd) {
+			for _, tri := range triangulate(poly) {
+				fills[i] = append(fills[i], toScaledVec2s(tri[:], scale)...)
+			}
+		}
+	}
+
+	if s.fillCache == nil || len(s.fillCache) >= svgFillCacheSize {
+		s.fillCache = make(map[float64][][]imgui.Vec2)
+	}
+	s.fillCache[scale] = fills
+	return fills
+}
+
+func toScaledVec2s(points []svgPoint, scale float64) []imgui.Vec2 {
+	result := make([]imgui.Vec2, len(points))
+	for i, p := range points {
+		result[i] = p.vec2(scale)
+	}
+	return result
+}
+
+func polygonArea(poly []svgPoint) float64 {
+	area := 0.0
+	for i := range poly {
+		area += poly[i].cross(poly[(i+1)%len(poly)])
+	}
+	return area / 2
+}
+
+// cleanPolygon drops repeated points and the closing point.
+func cleanPolygon(poly []svgPoint) []svgPoint {
+	result := make([]svgPoint, 0, len(poly))
+	for _, p := range poly {
+		if len(result) == 0 || p.dist(result[len(result)-1]) > 1e-9 {
+			result = append(result, p)
+		}
+	}
+	for len(result) > 1 && result[0].dist(result[len(result)-1]) <= 1e-9 {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+func isConvex(poly []svgPoint) bool {
+	sign := 0.0
+	for i := range poly {
+		a, b, c := poly[i], poly[(i+1)%len(poly)], poly[(i+2)%len(poly)]
+		cross := b.sub(a).cross(c.sub(b))
+		if cross == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = cross
+		} else if (cross > 0) != (sign > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func pointInPolygon(p svgPoint, poly []svgPoint) bool {
+	inside := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		a, b := poly[i], poly[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// groupPolygons finds the holes of a compound path and merges them into their outlines,
+// so each resulting polygon can be triangulated on its own.
+// A subpath inside another one is a hole with the even-odd rule, with the nonzero rule if it winds the other way.
+func groupPolygons(polygons [][]svgPoint, evenOdd bool) [][]svgPoint {
+	type group struct {
+		outer []svgPoint
+		area  float64
+		holes [][]svgPoint
+		hole  bool
+	}
+
+	groups := make([]*group, len(polygons))
+	for i, poly := range polygons {
+		groups[i] = &group{outer: poly, area: polygonArea(poly)}
+	}
+
+	// Visit larger polygons first, so parents are classified before their children.
+	for i := 1; i < len(groups); i++ {
+		for j := i; j > 0 && math.Abs(groups[j].area) > math.Abs(groups[j-1].area); j-- {
+			groups[j], groups[j-1] = groups[j-1], groups[j]
+		}
+	}
+
+	var result []*group
+	for i, g := range groups {
+		var parent *group
+		for j := i - 1; j >= 0; j-- {
+			if pointInPolygon(g.outer[0], groups[j].outer) {
+				parent = groups[j]
+				break
+			}
+		}
+
+		if parent != nil && !parent.hole && (evenOdd || (g.area > 0) != (parent.area > 0)) {
+			g.hole = true
+			parent.holes = append(parent.holes, g.outer)
+			continue
+		}
+		if parent != nil && !parent.hole {
+			// The nonzero winding of the parent fills it already, drawing it again would blend twice.
+			continue
+		}
+		result = append(result, g)
+	}
+
+	merged := make([][]svgPoint, len(result))
+	for i, g := range result {
+		merged[i] = mergeHoles(g.outer, g.holes)
+	}
+	return merged
+}
+
+// mergeHoles connects each hole to the outline by a bridge of two coincident edges.
+func mergeHoles(outer []svgPoint, holes [][]svgPoint) []svgPoint {
+	if polygonArea(outer) < 0 {
+		outer = reversePolygon(outer)
+	}
+
+	// Bridge holes from right to left, bridges of later holes can't cross earlier ones then.
+	for i := 1; i < len(holes); i++ {
+		for j := i; j > 0 && maxX(holes[j]) > maxX(holes[j-1]); j-- {
+			holes[j], holes[j-1] = holes[j-1], holes[j]
+		}
+	}
+
+	for h, hole := range holes {
+		if polygonArea(hole) > 0 {
+			hole = reversePolygon(hole)
+		}
+
+		hi := 0
+		for i, p := range hole {
+			if p.X > hole[hi].X {
+				hi = i
+			}
+		}
+		hp := hole[hi]
+
+		best := -1
+		bestDist := math.Inf(1)
+		for i, p := range outer {
+			d := p.dist(hp)
+			if d >= bestDist || !bridgeIsClear(hp, p, outer, holes[h:]) {
+				continue
+			}
+			best, bestDist = i, d
+		}
+		if best < 0 {
+			continue
+		}
+
+		result := make([]svgPoint, 0, len(outer)+len(hole)+2)
+		result = append(result, outer[:best+1]...)
+		for i := 0; i <= len(hole); i++ {
+			result = append(result, hole[(hi+i)%len(hole)])
+		}
+		result = append(result, outer[best:]...)
+		outer = result
+	}
+
+	return outer
+}
+
+func maxX(poly []svgPoint) float64 {
+	x := math.Inf(-1)
+	for _, p := range poly {
+		x = math.Max(x, p.X)
+	}
+	return x
+}
+
+func reversePolygon(poly []svgPoint) []svgPoint {
+	result := make([]svgPoint, len(poly))
+	for i, p := range poly {
+		result[len(poly)-1-i] = p
+	}
+	return result
+}
+
+// bridgeIsClear reports whether the segment a-b crosses none of the edges of the polygons.
+func bridgeIsClear(a, b svgPoint, outer []svgPoint, holes [][]svgPoint) bool {
+	check := func(poly []svgPoint) bool {
+		for i := range poly {
+			p, q := poly[i], poly[(i+1)%len(poly)]
+			if p == a || p == b || q == a || q == b {
+				continue
+			}
+			if segmentsIntersect(a, b, p, q) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !check(outer) {
+		return false
+	}
+	for _, hole := range holes {
+		if !check(hole) {
+			return false
+		}
+	}
+	return true
+}
+
+func segmentsIntersect(a, b, c, d svgPoint) bool {
+	d1 := b.sub(a).cross(c.sub(a))
+	d2 := b.sub(a).cross(d.sub(a))
+	d3 := d.sub(c).cross(a.sub(c))
+	d4 := d.sub(c).cross(b.sub(c))
+	return ((d1 > 0) != (d2 > 0)) && ((d3 > 0) != (d4 > 0))
+}
+
+// triangulate splits a simple polygon into triangles by ear clipping.
+func triangulate(poly []svgPoint) [][3]svgPoint {
+	if len(poly) < 3 {
+		return nil
+	}
+	if polygonArea(poly) < 0 {
+		poly = reversePolygon(poly)
+	}
+
+	indices := make([]int, len(poly))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	triangles := make([][3]svgPoint, 0, len(poly)-2)
+	for len(indices) > 3 {
+		n := len(indices)
+		ear := -1
+		for i := 0; i < n && ear < 0; i++ {
+			a, b, c := poly[indices[(i+n-1)%n]], poly[indices[i]], poly[indices[(i+1)%n]]
+			if b.sub(a).cross(c.sub(b)) <= 0 {
+				// Reflex or degenerate corner.
+				continue
+			}
+
+			isEar := true
+			for j := 0; j < n && isEar; j++ {
+				p := poly[indices[j]]
+				if p == a || p == b || p == c {
+					continue
+				}
+				isEar = !pointInTriangle(p, a, b, c)
+			}
+			if isEar {
+				ear = i
+			}
+		}
+
+		// Without an ear the polygon is self-intersecting, clip any corner to make progress.
+		if ear < 0 {
+			ear = 0
+		}
+
+		triangles = append(triangles, [3]svgPoint{poly[indices[(ear+n-1)%n]], poly[indices[ear]], poly[indices[(ear+1)%n]]})
+		indices = append(indices[:ear], indices[ear+1:]...)
+	}
+	triangles = append(triangles, [3]svgPoint{poly[indices[0]], poly[indices[1]], poly[indices[2]]})
+
+	return triangles
+}
+
+func pointInTriangle(p, a, b, c svgPoint) bool {
+	d1 := b.sub(a).cross(p.sub(a))
+	d2 := c.sub(b).cross(p.sub(b))
+	d3 := a.sub(c).cross(p.sub(c))
+	return d1 >= 0 && d2 >= 0 && d3 >= 0
+}
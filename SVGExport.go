@@ -0,0 +1,378 @@
+package giu
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math"
+	"sync"
+	"unsafe"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// SVGExportOptions configures ExportSVG and CaptureSVG.
+type SVGExportOptions struct {
+	// Region limits the export to a rectangle of the display, e.g. the one of a window
+	// taken from imgui.WindowPos and imgui.WindowSize. The whole display is exported if it's empty.
+	Region image.Rectangle
+	// Images returns the pixels of textures drawn by Image widgets or Canvas.AddImage,
+	// id is the one returned by Texture.ID. Textures are exported as gray boxes if it's nil or returns nil.
+	Images func(id imgui.TextureID) image.Image
+}
+
+type svgCapture struct {
+	w       io.Writer
+	options SVGExportOptions
+	done    func(err error)
+}
+
+var pendingCaptures struct {
+	mu       sync.Mutex
+	captures []svgCapture
+}
+
+// CaptureSVG exports the next rendered frame to w, see ExportSVG, and passes the result to done,
+// which is invoked in mainthread. It doesn't block, so it can be called from the ui loop.
+func CaptureSVG(w io.Writer, options SVGExportOptions, done func(err error)) {
+	pendingCaptures.mu.Lock()
+	pendingCaptures.captures = append(pendingCaptures.captures, svgCapture{w: w, options: options, done: done})
+	pendingCaptures.mu.Unlock()
+
+	Update()
+}
+
+// runCaptures exports drawData for pending captures, it must be called in mainthread after rendering.
+func runCaptures(drawData imgui.DrawData) {
+	pendingCaptures.mu.Lock()
+	captures := pendingCaptures.captures
+	pendingCaptures.captures = nil
+	pendingCaptures.mu.Unlock()
+
+	for _, c := range captures {
+		err := ExportSVG(c.w, drawData, c.options)
+		if c.done != nil {
+			c.done(err)
+		}
+	}
+}
+
+// ExportSVG writes the triangles of drawData (e.g. imgui.RenderedDrawData()) to w as an SVG document.
+// Solid triangles are merged into paths, anti-aliasing fringes are left out as vector output doesn't need them.
+// Text is exported as references into the embedded font atlas, tinted by filters.
+//
+// Note: this function must be called in mainthread while drawData is valid, use CaptureSVG otherwise.
+func ExportSVG(w io.Writer, drawData imgui.DrawData, options SVGExportOptions) error {
+	if !drawData.Valid() {
+		return fmt.Errorf("draw data is not valid")
+	}
+
+	region := options.Region
+	if region.Empty() {
+		if Context.platform == nil {
+			return fmt.Errorf("no region given and no display to export")
+		}
+		size := Context.platform.DisplaySize()
+		region = image.Rect(0, 0, int(math.Ceil(float64(size[0]))), int(math.Ceil(float64(size[1]))))
+	}
+
+	e := &svgExporter{
+		options:  options,
+		atlasID:  imgui.CurrentIO().Fonts().TextureID(),
+		clipIDs:  make(map[imgui.Vec4]int),
+		filters:  make(map[svgTint]int),
+		textures: make(map[imgui.TextureID]string),
+	}
+
+	vertexSize, posOffset, uvOffset, colOffset := imgui.VertexBufferLayout()
+	indexSize := imgui.IndexBufferLayout()
+
+	for _, list := range drawData.CommandLists() {
+		vertexPtr, vertexBytes := list.VertexBuffer()
+		indexPtr, indexBytes := list.IndexBuffer()
+		vertices := rawBytes(vertexPtr, vertexBytes)
+		indices := rawBytes(indexPtr, indexBytes)
+
+		vertex := func(i int) svgVertex {
+			v := vertices[i*vertexSize:]
+			return svgVertex{
+				pos: imgui.Vec2{X: readFloat32(v[posOffset:]), Y: readFloat32(v[posOffset+4:])},
+				uv:  imgui.Vec2{X: readFloat32(v[uvOffset:]), Y: readFloat32(v[uvOffset+4:])},
+				col: binary.LittleEndian.Uint32(v[colOffset:]),
+			}
+		}
+		index := func(i int) int {
+			if indexSize == 2 {
+				return int(binary.LittleEndian.Uint16(indices[i*2:]))
+			}
+			return int(binary.LittleEndian.Uint32(indices[i*4:]))
+		}
+
+		offset := 0
+		for _, cmd := range list.Commands() {
+			count := cmd.ElementCount()
+			if cmd.HasUserCallback() {
+				offset += count
+				continue
+			}
+
+			clip := cmd.ClipRect()
+			if clip.Z > float32(region.Min.X) && clip.X < float32(region.Max.X) && clip.W > float32(region.Min.Y) && clip.Y < float32(region.Max.Y) {
+				var tris []svgTriangle
+				for i := offset; i+2 < offset+count; i += 3 {
+					tris = append(tris, svgTriangle{
+						indices:  [3]int{index(i), index(i + 1), index(i + 2)},
+						vertices: [3]svgVertex{vertex(index(i)), vertex(index(i + 1)), vertex(index(i + 2))},
+					})
+				}
+				if err := e.command(cmd.TextureID(), clip, tris); err != nil {
+					return err
+				}
+			}
+			offset += count
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d" viewBox="%d %d %d %d">`+"\n",
+		region.Dx(), region.Dy(), region.Min.X, region.Min.Y, region.Dx(), region.Dy())
+	if e.atlasUsed {
+		if err := e.writeAtlas(); err != nil {
+			return err
+		}
+	}
+	if e.defs.Len() > 0 {
+		fmt.Fprintf(out, "<defs>\n%s</defs>\n", e.defs.Bytes())
+	}
+	out.Write(e.body.Bytes())
+	fmt.Fprintln(out, "</svg>")
+	return out.Flush()
+}
+
+func rawBytes(ptr unsafe.Pointer, size int) []byte {
+	if ptr == nil || size == 0 {
+		return nil
+	}
+	return (*[1 << 30]byte)(ptr)[:size:size]
+}
+
+func readFloat32(b []byte) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(b))
+}
+
+type svgVertex struct {
+	pos imgui.Vec2
+	uv  imgui.Vec2
+	col uint32 // ABGR, as packed by imgui
+}
+
+type svgTriangle struct {
+	indices  [3]int
+	vertices [3]svgVertex
+}
+
+type svgTint struct {
+	col   uint32
+	atlas bool
+}
+
+type svgExporter struct {
+	options   SVGExportOptions
+	atlasID   imgui.TextureID
+	atlasUsed bool
+	clipIDs   map[imgui.Vec4]int
+	filters   map[svgTint]int
+	textures  map[imgui.TextureID]string
+	defs      bytes.Buffer
+	body      bytes.Buffer
+}
+
+// command exports the triangles of one draw command.
+func (e *svgExporter) command(texture imgui.TextureID, clip imgui.Vec4, tris []svgTriangle) error {
+	clipID, ok := e.clipIDs[clip]
+	if !ok {
+		clipID = len(e.clipIDs)
+		e.clipIDs[clip] = clipID
+		fmt.Fprintf(&e.defs, `<clipPath id="clip%d"><rect x="%g" y="%g" width="%g" height="%g"/></clipPath>`+"\n",
+			clipID, clip.X, clip.Y, clip.Z-clip.X, clip.W-clip.Y)
+	}
+	fmt.Fprintf(&e.body, `<g clip-path="url(#clip%d)">`+"\n", clipID)
+
+	// Solid triangles of the same color are merged into one path, so no seams show between them.
+	var path bytes.Buffer
+	var pathColor uint32
+	flush := func() {
+		if path.Len() > 0 {
+			fmt.Fprintf(&e.body, `<path d="%s" %s/>`+"\n", path.Bytes(), svgFill(pathColor))
+			path.Reset()
+		}
+	}
+
+	for i := 0; i < len(tris); i++ {
+		t := tris[i]
+
+		// Quads of images and glyphs are emitted as two triangles sharing a diagonal: (a,b,c) and (a,c,d).
+		if !t.solid() && i+1 < len(tris) {
+			next := tris[i+1]
+			if next.indices[0] == t.indices[0] && next.indices[1] == t.indices[2] {
+				flush()
+				if err := e.quad(texture, t.vertices[0], t.vertices[1], t.vertices[2], next.vertices[2]); err != nil {
+					return err
+				}
+				i++
+				continue
+			}
+		}
+
+		// Anti-aliasing fringes fade out to transparent vertices.
+		col := t.vertices[0].col
+		if t.vertices[0].col>>24 == 0 || t.vertices[1].col>>24 == 0 || t.vertices[2].col>>24 == 0 {
+			continue
+		}
+		if col != pathColor {
+			flush()
+			pathColor = col
+		}
+		p := t.vertices
+		fmt.Fprintf(&path, "M%g %gL%g %gL%g %gZ", p[0].pos.X, p[0].pos.Y, p[1].pos.X, p[1].pos.Y, p[2].pos.X, p[2].pos.Y)
+	}
+	flush()
+
+	fmt.Fprintln(&e.body, "</g>")
+	return nil
+}
+
+// solid reports whether all vertices sample the same texel, like geometry using the white pixel of the font atlas.
+func (t svgTriangle) solid() bool {
+	return t.vertices[0].uv == t.vertices[1].uv && t.vertices[1].uv == t.vertices[2].uv
+}
+
+// quad exports the texture mapped to the quad a, b, c, d, which are clockwise from the upper left corner.
+func (e *svgExporter) quad(texture imgui.TextureID, a, b, c, d svgVertex) error {
+	var ref string
+	var width, height float32
+
+	if texture == e.atlasID {
+		atlas := imgui.CurrentIO().Fonts().TextureDataAlpha8()
+		e.atlasUsed = true
+		ref, width, height = "#atlas", float32(atlas.Width), float32(atlas.Height)
+	} else {
+		var img image.Image
+		if e.options.Images != nil {
+			img = e.options.Images(texture)
+		}
+		if img == nil {
+			fmt.Fprintf(&e.body, `<path d="M%g %gL%g %gL%g %gL%g %gZ" fill="#808080"/>`+"\n",
+				a.pos.X, a.pos.Y, b.pos.X, b.pos.Y, c.pos.X, c.pos.Y, d.pos.X, d.pos.Y)
+			return nil
+		}
+		var err error
+		if ref, err = e.texture(texture, img); err != nil {
+			return err
+		}
+		width, height = float32(img.Bounds().Dx()), float32(img.Bounds().Dy())
+	}
+
+	// Map the texture region between the uvs of a and c onto the parallelogram a, b, d.
+	u0, v0 := a.uv.X*width, a.uv.Y*height
+	du, dv := c.uv.X*width-u0, c.uv.Y*height-v0
+	if du == 0 || dv == 0 {
+		return nil
+	}
+	// Flipped uvs start the view box of the nested svg, which clips to the region, at the other corner.
+	viewX, viewY := u0, v0
+	if du < 0 {
+		viewX += du
+	}
+	if dv < 0 {
+		viewY += dv
+	}
+	ux := imgui.Vec2{X: (b.pos.X - a.pos.X) / du, Y: (b.pos.Y - a.pos.Y) / du}
+	vy := imgui.Vec2{X: (d.pos.X - a.pos.X) / dv, Y: (d.pos.Y - a.pos.Y) / dv}
+	origin := a.pos.Plus(ux.Times(viewX - u0)).Plus(vy.Times(viewY - v0))
+	matrix := [6]float32{ux.X, ux.Y, vy.X, vy.Y, origin.X, origin.Y}
+
+	fmt.Fprintf(&e.body, `<g transform="matrix(%g %g %g %g %g %g)"><svg width="%g" height="%g" viewBox="%g %g %g %g" preserveAspectRatio="none"><use xlink:href="%s"%s/></svg></g>`+"\n",
+		matrix[0], matrix[1], matrix[2], matrix[3], matrix[4], matrix[5],
+		float32(math.Abs(float64(du))), float32(math.Abs(float64(dv))), viewX, viewY, float32(math.Abs(float64(du))), float32(math.Abs(float64(dv))),
+		ref, e.tint(a.col, texture == e.atlasID))
+	return nil
+}
+
+// tint returns the filter attribute coloring a texture with col. Glyphs of the white font atlas
+// take the color, other textures are multiplied by it.
+func (e *svgExporter) tint(col uint32, atlas bool) string {
+	if !atlas && col == 0xffffffff {
+		return ""
+	}
+
+	key := svgTint{col, atlas}
+	id, ok := e.filters[key]
+	if !ok {
+		id = len(e.filters)
+		e.filters[key] = id
+
+		r, g, b, a := svgColorComponents(col)
+		values := fmt.Sprintf("%g 0 0 0 0 0 %g 0 0 0 0 0 %g 0 0 0 0 0 %g 0", r, g, b, a)
+		if atlas {
+			values = fmt.Sprintf("0 0 0 0 %g 0 0 0 0 %g 0 0 0 0 %g 0 0 0 %g 0", r, g, b, a)
+		}
+		fmt.Fprintf(&e.defs, `<filter id="tint%d" color-interpolation-filters="sRGB"><feColorMatrix type="matrix" values="%s"/></filter>`+"\n", id, values)
+	}
+	return fmt.Sprintf(` filter="url(#tint%d)"`, id)
+}
+
+// texture embeds img once and returns the reference to it.
+func (e *svgExporter) texture(id imgui.TextureID, img image.Image) (string, error) {
+	if ref, ok := e.textures[id]; ok {
+		return ref, nil
+	}
+
+	ref := fmt.Sprintf("tex%d", len(e.textures))
+	if err := e.embedImage(ref, img); err != nil {
+		return "", err
+	}
+	e.textures[id] = "#" + ref
+	return "#" + ref, nil
+}
+
+func (e *svgExporter) writeAtlas() error {
+	atlas := imgui.CurrentIO().Fonts().TextureDataAlpha8()
+	img := image.NewNRGBA(image.Rect(0, 0, atlas.Width, atlas.Height))
+	alpha := rawBytes(atlas.Pixels, atlas.Width*atlas.Height)
+	for i, a := range alpha {
+		img.Pix[i*4] = 0xff
+		img.Pix[i*4+1] = 0xff
+		img.Pix[i*4+2] = 0xff
+		img.Pix[i*4+3] = a
+	}
+	return e.embedImage("atlas", img)
+}
+
+func (e *svgExporter) embedImage(id string, img image.Image) error {
+	var data bytes.Buffer
+	if err := png.Encode(&data, img); err != nil {
+		return err
+	}
+	fmt.Fprintf(&e.defs, `<image id="%s" width="%d" height="%d" xlink:href="data:image/png;base64,%s"/>`+"\n",
+		id, img.Bounds().Dx(), img.Bounds().Dy(), base64.StdEncoding.EncodeToString(data.Bytes()))
+	return nil
+}
+
+func svgColorComponents(col uint32) (r, g, b, a float32) {
+	return float32(col&0xff) / 255, float32(col>>8&0xff) / 255, float32(col>>16&0xff) / 255, float32(col>>24) / 255
+}
+
+func svgFill(col uint32) string {
+	_, _, _, a := svgColorComponents(col)
+	fill := fmt.Sprintf(`fill="#%02x%02x%02x"`, col&0xff, col>>8&0xff, col>>16&0xff)
+	if a < 1 {
+		fill += fmt.Sprintf(` fill-opacity="%g"`, a)
+	}
+	return fill
+}
@@ -0,0 +1,245 @@
+package giu
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// Sub-scanlines per pixel row, for anti-aliasing.
+const svgSubsamples = 5
+
+// Rasterize renders svg into an image of its size multiplied by scale.
+// Strokes are drawn with round joins and caps.
+func (s *SVG) Rasterize(scale float32) *image.RGBA {
+	sc := float64(scale)
+	width := int(math.Ceil(s.width * sc))
+	height := int(math.Ceil(s.height * sc))
+	if width <= 0 || height <= 0 {
+		return image.NewRGBA(image.Rect(0, 0, 0, 0))
+	}
+
+	r := newSVGRasterizer(width, height)
+	for _, shape := range s.shapes {
+		if shape.hasFill {
+			var polygons [][]svgPoint
+			for _, sp := range shape.subpaths {
+				polygons = append(polygons, scalePolygon(sp.flatten(sc, 0.5), sc))
+			}
+			r.fill(polygons, shape.evenOdd)
+			r.composite(float64(shape.fill.R), float64(shape.fill.G), float64(shape.fill.B), float64(shape.fill.A))
+		}
+
+		if shape.hasStroke {
+			var polygons [][]svgPoint
+			for _, sp := range shape.subpaths {
+				if len(sp.segments) == 0 {
+					continue
+				}
+				points := cleanPolygon(scalePolygon(sp.flatten(sc, 0.5), sc))
+				polygons = append(polygons, strokePolygons(points, sp.closed, shape.strokeWidth*sc/2)...)
+			}
+			r.fill(polygons, false)
+			r.composite(float64(shape.stroke.R), float64(shape.stroke.G), float64(shape.stroke.B), float64(shape.stroke.A))
+		}
+	}
+
+	return r.image()
+}
+
+// NewTextureFromSVG rasterizes svg at scale and the content scale of the monitor, and creates a texture from it,
+// so it stays sharp on HiDPI monitors when shown at svg.Size() * scale * Context.GetScale().
+// Note: this function has to be invokded in a go routine, see NewTextureFromRgba.
+func NewTextureFromSVG(svg *SVG, scale float32) (*Texture, error) {
+	return NewTextureFromRgba(svg.Rasterize(scale * Context.GetContentScale()))
+}
+
+func scalePolygon(poly []svgPoint, scale float64) []svgPoint {
+	for i := range poly {
+		poly[i] = poly[i].mul(scale)
+	}
+	return poly
+}
+
+// strokePolygons covers a stroke of halfWidth around points with a quad per line and a circle per joint.
+// All polygons wind the same way, so filling them with the nonzero rule draws their union.
+func strokePolygons(points []svgPoint, closed bool, halfWidth float64) [][]svgPoint {
+	var polygons [][]svgPoint
+	n := len(points)
+	if n == 0 || halfWidth <= 0 {
+		return nil
+	}
+
+	segments := n - 1
+	if closed && n > 2 {
+		segments = n
+	}
+	for i := 0; i < segments; i++ {
+		a, b := points[i], points[(i+1)%n]
+		length := a.dist(b)
+		if length == 0 {
+			continue
+		}
+		normal := svgPoint{-(b.Y - a.Y) / length * halfWidth, (b.X - a.X) / length * halfWidth}
+		polygons = append(polygons, positivePolygon([]svgPoint{a.add(normal), b.add(normal), b.sub(normal), a.sub(normal)}))
+	}
+
+	steps := int(math.Max(8, math.Min(64, halfWidth*4)))
+	for _, p := range points {
+		circle := make([]svgPoint, steps)
+		for i := range circle {
+			sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(steps))
+			circle[i] = svgPoint{p.X + cos*halfWidth, p.Y + sin*halfWidth}
+		}
+		polygons = append(polygons, positivePolygon(circle))
+	}
+
+	return polygons
+}
+
+func positivePolygon(poly []svgPoint) []svgPoint {
+	if polygonArea(poly) < 0 {
+		return reversePolygon(poly)
+	}
+	return poly
+}
+
+type svgEdge struct {
+	x0, y0, x1, y1 float64
+	winding        int
+}
+
+type svgCrossing struct {
+	x       float64
+	winding int
+}
+
+// svgRasterizer renders polygons into a coverage mask, and composites it with colors into
+// a premultiplied floating point image.
+type svgRasterizer struct {
+	width, height int
+	coverage      []float64
+	pixels        []float64
+	crossings     []svgCrossing
+}
+
+func newSVGRasterizer(width, height int) *svgRasterizer {
+	return &svgRasterizer{
+		width:    width,
+		height:   height,
+		coverage: make([]float64, width*height),
+		pixels:   make([]float64, width*height*4),
+	}
+}
+
+// fill computes the coverage of the polygons, which are closed implicitly.
+func (r *svgRasterizer) fill(polygons [][]svgPoint, evenOdd bool) {
+	for i := range r.coverage {
+		r.coverage[i] = 0
+	}
+
+	var edges []svgEdge
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, poly := range polygons {
+		for i := range poly {
+			a, b := poly[i], poly[(i+1)%len(poly)]
+			if a.Y == b.Y {
+				continue
+			}
+			edge := svgEdge{a.X, a.Y, b.X, b.Y, 1}
+			if a.Y > b.Y {
+				edge = svgEdge{b.X, b.Y, a.X, a.Y, -1}
+			}
+			edges = append(edges, edge)
+			minY = math.Min(minY, edge.y0)
+			maxY = math.Max(maxY, edge.y1)
+		}
+	}
+	if len(edges) == 0 {
+		return
+	}
+
+	startRow := int(math.Max(0, math.Floor(minY)))
+	endRow := int(math.Min(float64(r.height), math.Ceil(maxY)))
+	weight := 1.0 / svgSubsamples
+
+	for row := startRow; row < endRow; row++ {
+		for sub := 0; sub < svgSubsamples; sub++ {
+			y := float64(row) + (float64(sub)+0.5)/svgSubsamples
+
+			r.crossings = r.crossings[:0]
+			for _, e := range edges {
+				if y >= e.y0 && y < e.y1 {
+					x := e.x0 + (y-e.y0)*(e.x1-e.x0)/(e.y1-e.y0)
+					r.crossings = append(r.crossings, svgCrossing{x, e.winding})
+				}
+			}
+			sort.Slice(r.crossings, func(i, j int) bool { return r.crossings[i].x < r.crossings[j].x })
+
+			winding := 0
+			for i, c := range r.crossings {
+				winding += c.winding
+				inside := winding != 0
+				if evenOdd {
+					inside = winding%2 != 0
+				}
+				if inside && i+1 < len(r.crossings) {
+					r.addSpan(row, c.x, r.crossings[i+1].x, weight)
+				}
+			}
+		}
+	}
+}
+
+// addSpan adds weight to the coverage of the pixels between x0 and x1, partially covered pixels get a share.
+func (r *svgRasterizer) addSpan(row int, x0, x1, weight float64) {
+	x0 = math.Max(0, x0)
+	x1 = math.Min(float64(r.width), x1)
+	if x0 >= x1 {
+		return
+	}
+
+	line := r.coverage[row*r.width : (row+1)*r.width]
+	i0, i1 := int(x0), int(x1)
+	if i0 == i1 {
+		line[i0] += (x1 - x0) * weight
+		return
+	}
+
+	line[i0] += (float64(i0+1) - x0) * weight
+	for i := i0 + 1; i < i1; i++ {
+		line[i] += weight
+	}
+	if i1 < r.width {
+		line[i1] += (x1 - float64(i1)) * weight
+	}
+}
+
+// composite blends the color through the coverage mask over the image.
+func (r *svgRasterizer) composite(red, green, blue, alpha float64) {
+	for i, c := range r.coverage {
+		if c <= 0 {
+			continue
+		}
+		a := math.Min(1, c) * alpha / 255
+		p := r.pixels[i*4 : i*4+4]
+		p[0] = red/255*a + p[0]*(1-a)
+		p[1] = green/255*a + p[1]*(1-a)
+		p[2] = blue/255*a + p[2]*(1-a)
+		p[3] = a + p[3]*(1-a)
+	}
+}
+
+// image returns the pixels, premultiplied like any image.RGBA.
+func (r *svgRasterizer) image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, r.width, r.height))
+	channel := func(v float64) uint8 { return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255)) }
+	for i := 0; i < r.width*r.height; i++ {
+		p := r.pixels[i*4 : i*4+4]
+		img.Pix[i*4] = channel(p[0])
+		img.Pix[i*4+1] = channel(p[1])
+		img.Pix[i*4+2] = channel(p[2])
+		img.Pix[i*4+3] = channel(p[3])
+	}
+	return img
+}
@@ -0,0 +1,343 @@
+package giu
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/AllenDang/giu/imgui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTestSVG(t *testing.T, body string) *SVG {
+	svg, err := ParseSVG([]byte(`<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">` + body + `</svg>`))
+	require.NoError(t, err)
+	return svg
+}
+
+// subpathEnds returns the start and the segment end points of the subpaths which draw something.
+func subpathEnds(subpaths []svgSubpath) (ends [][]svgPoint, closed []bool) {
+	for _, sp := range subpaths {
+		if len(sp.segments) == 0 {
+			continue
+		}
+		points := []svgPoint{sp.start}
+		for _, seg := range sp.segments {
+			points = append(points, seg.to)
+		}
+		ends = append(ends, points)
+		closed = append(closed, sp.closed)
+	}
+	return ends, closed
+}
+
+func assertPointsInDelta(t *testing.T, expected, actual [][]svgPoint) {
+	require.Equal(t, len(expected), len(actual), "subpath count")
+	for i := range expected {
+		require.Equal(t, len(expected[i]), len(actual[i]), "point count of subpath %d", i)
+		for j := range expected[i] {
+			assert.InDelta(t, expected[i][j].X, actual[i][j].X, 1e-9, "x of point %d of subpath %d", j, i)
+			assert.InDelta(t, expected[i][j].Y, actual[i][j].Y, 1e-9, "y of point %d of subpath %d", j, i)
+		}
+	}
+}
+
+func TestParseSVGPathData(t *testing.T) {
+	tt := []struct {
+		name   string
+		d      string
+		ends   [][]svgPoint
+		closed []bool
+	}{
+		{name: "absolute", d: "M0 0L10 0L10 10Z", ends: [][]svgPoint{{{0, 0}, {10, 0}, {10, 10}}}, closed: []bool{true}},
+		{name: "relative", d: "m1 1l2 0l0 2z", ends: [][]svgPoint{{{1, 1}, {3, 1}, {3, 3}}}, closed: []bool{true}},
+		{name: "horizontal and vertical", d: "M0 0H5V5h-5v-5", ends: [][]svgPoint{{{0, 0}, {5, 0}, {5, 5}, {0, 5}, {0, 0}}}, closed: []bool{false}},
+		{name: "implicit lines", d: "M0 0 1 1 2 0", ends: [][]svgPoint{{{0, 0}, {1, 1}, {2, 0}}}, closed: []bool{false}},
+		{name: "compact numbers", d: "M10-5.5.5.5L1e1,0", ends: [][]svgPoint{{{10, -5.5}, {0.5, 0.5}, {10, 0}}}, closed: []bool{false}},
+		{name: "subpaths", d: "M0 0L1 0ZM5 5l1 0", ends: [][]svgPoint{{{0, 0}, {1, 0}}, {{5, 5}, {6, 5}}}, closed: []bool{true, false}},
+		{name: "line after close", d: "M1 1L2 1ZL1 2", ends: [][]svgPoint{{{1, 1}, {2, 1}}, {{1, 1}, {1, 2}}}, closed: []bool{true, false}},
+		{name: "cubic", d: "M0 0C0 5 10 5 10 0S20 -5 20 0", ends: [][]svgPoint{{{0, 0}, {10, 0}, {20, 0}}}, closed: []bool{false}},
+		{name: "quadratic", d: "M0 0Q5 10 10 0T20 0", ends: [][]svgPoint{{{0, 0}, {10, 0}, {20, 0}}}, closed: []bool{false}},
+		{name: "arc in quarters", d: "M0 0A5 5 0 0 1 10 0", ends: [][]svgPoint{{{0, 0}, {5, -5}, {10, 0}}}, closed: []bool{false}},
+		{name: "arc with compact flags", d: "M0 0a5 5 0 0010 0", ends: [][]svgPoint{{{0, 0}, {5, 5}, {10, 0}}}, closed: []bool{false}},
+		{name: "arc with radius too small", d: "M0 0A1 1 0 0 1 10 0", ends: [][]svgPoint{{{0, 0}, {5, -5}, {10, 0}}}, closed: []bool{false}},
+		{name: "arc with zero radius", d: "M0 0A0 5 0 0 1 10 0", ends: [][]svgPoint{{{0, 0}, {10, 0}}}, closed: []bool{false}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			var b svgPathBuilder
+			require.NoError(t, b.parse(td.d))
+			ends, closed := subpathEnds(b.subpaths)
+			assertPointsInDelta(t, td.ends, ends)
+			assert.Equal(t, td.closed, closed)
+		})
+	}
+}
+
+func TestParseSVGPathDataCurves(t *testing.T) {
+	var b svgPathBuilder
+	require.NoError(t, b.parse("M0 0Q6 9 12 0C12 3 15 3 15 0S18 -3 18 0"))
+	segments := b.subpaths[0].segments
+	require.Len(t, segments, 3)
+
+	// Quadratic curves become cubic ones with control points 2/3 of the way to the quadratic one.
+	assert.Equal(t, svgPoint{4, 6}, segments[0].c1)
+	assert.Equal(t, svgPoint{8, 6}, segments[0].c2)
+	// Smooth curves reflect the last control point.
+	assert.Equal(t, svgPoint{15, -3}, segments[2].c1)
+}
+
+func TestParseSVGPathDataErrors(t *testing.T) {
+	tt := []string{"L0 0", "M0 0X1 1", "M0 0L1", "M0 0A5 5 0 2 1 10 0", "M0 0L1 1e"}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td, func(t *testing.T) {
+			var b svgPathBuilder
+			assert.Error(t, b.parse(td))
+		})
+	}
+}
+
+func TestParseSVGTransform(t *testing.T) {
+	tt := []struct {
+		value string
+		want  svgPoint
+	}{
+		{value: "", want: svgPoint{1, 2}},
+		{value: "translate(10,20)", want: svgPoint{11, 22}},
+		{value: "translate(10)", want: svgPoint{11, 2}},
+		{value: "scale(2)", want: svgPoint{2, 4}},
+		{value: "scale(2 3)", want: svgPoint{2, 6}},
+		{value: "rotate(90)", want: svgPoint{-2, 1}},
+		{value: "rotate(90 1 1)", want: svgPoint{0, 1}},
+		{value: "skewX(45)", want: svgPoint{3, 2}},
+		{value: "skewY(45)", want: svgPoint{1, 3}},
+		{value: "matrix(1 0 0 1 5 5)", want: svgPoint{6, 7}},
+		{value: "translate(10) scale(2)", want: svgPoint{12, 4}},
+		{value: "scale(2),translate(10)", want: svgPoint{22, 4}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.value, func(t *testing.T) {
+			m, err := parseSVGTransform(td.value)
+			require.NoError(t, err)
+			p := m.apply(svgPoint{1, 2})
+			assert.InDelta(t, td.want.X, p.X, 1e-9)
+			assert.InDelta(t, td.want.Y, p.Y, 1e-9)
+		})
+	}
+
+	for _, value := range []string{"spin(1)", "matrix(1 2)", "scale(2"} {
+		_, err := parseSVGTransform(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseSVGNestedTransforms(t *testing.T) {
+	svg := parseTestSVG(t, `<g transform="translate(10 0)"><g transform="scale(2)"><rect x="1" y="1" width="1" height="1" stroke-width="2" stroke="red"/></g></g>`)
+	require.Len(t, svg.shapes, 1)
+	ends, _ := subpathEnds(svg.shapes[0].subpaths)
+	assertPointsInDelta(t, [][]svgPoint{{{12, 2}, {14, 2}, {14, 4}, {12, 4}}}, ends)
+	assert.Equal(t, 4.0, svg.shapes[0].strokeWidth, "stroke width expected to be scaled")
+}
+
+func TestParseSVGViewBox(t *testing.T) {
+	svg, err := ParseSVG([]byte(`<svg width="20" height="10" viewBox="0 0 10 10"><rect width="10" height="10"/></svg>`))
+	require.NoError(t, err)
+	width, height := svg.Size()
+	assert.Equal(t, float32(20), width)
+	assert.Equal(t, float32(10), height)
+
+	// The view box is centered, keeping its aspect ratio.
+	ends, _ := subpathEnds(svg.shapes[0].subpaths)
+	assertPointsInDelta(t, [][]svgPoint{{{5, 0}, {15, 0}, {15, 10}, {5, 10}}}, ends)
+}
+
+func TestParseSVGPaint(t *testing.T) {
+	parent := svgPaint{color: color.RGBA{1, 2, 3, 255}}
+	tt := []struct {
+		value string
+		want  svgPaint
+	}{
+		{value: "#f00", want: svgPaint{color: color.RGBA{255, 0, 0, 255}}},
+		{value: "#f008", want: svgPaint{color: color.RGBA{255, 0, 0, 136}}},
+		{value: "#00FF00", want: svgPaint{color: color.RGBA{0, 255, 0, 255}}},
+		{value: "#0000ff80", want: svgPaint{color: color.RGBA{0, 0, 255, 128}}},
+		{value: "rgb(255, 128, 0)", want: svgPaint{color: color.RGBA{255, 128, 0, 255}}},
+		{value: "rgb(100%,50%,0%)", want: svgPaint{color: color.RGBA{255, 128, 0, 255}}},
+		{value: "rgba(255, 128, 0, 0.5)", want: svgPaint{color: color.RGBA{255, 128, 0, 128}}},
+		{value: "rgb(255 128 0 / 25%)", want: svgPaint{color: color.RGBA{255, 128, 0, 64}}},
+		{value: "rgb(300, -5, 0)", want: svgPaint{color: color.RGBA{255, 0, 0, 255}}},
+		{value: "hsl(120, 100%, 50%)", want: svgPaint{color: color.RGBA{0, 255, 0, 255}}},
+		{value: "hsla(240deg 100% 25% / 0.5)", want: svgPaint{color: color.RGBA{0, 0, 128, 128}}},
+		{value: "hsl(0, 0%, 100%)", want: svgPaint{color: color.RGBA{255, 255, 255, 255}}},
+		{value: "rebeccapurple", want: svgPaint{color: color.RGBA{102, 51, 153, 255}}},
+		{value: " CornflowerBlue ", want: svgPaint{color: color.RGBA{100, 149, 237, 255}}},
+		{value: "none", want: svgPaint{none: true}},
+		{value: "url(#gradient)", want: svgPaint{none: true}},
+		{value: "transparent", want: svgPaint{}},
+		{value: "currentColor", want: parent},
+		{value: "inherit", want: parent},
+		{value: "bogus", want: parent},
+		{value: "#12", want: parent},
+		{value: "#gg0000", want: parent},
+		{value: "rgb(1, 2)", want: parent},
+		{value: "cmyk(0, 0, 0, 0)", want: parent},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.value, func(t *testing.T) {
+			assert.Equal(t, td.want, parseSVGPaint(td.value, parent))
+		})
+	}
+}
+
+func TestParseSVGStyle(t *testing.T) {
+	svg := parseTestSVG(t, `
+		<g fill="blue" opacity="0.5">
+			<rect width="1" height="1"/>
+			<rect width="1" height="1" fill="rgba(255, 0, 0, 0.5)"/>
+			<rect width="1" height="1" style="fill: unknown-color; fill-opacity: 0.5"/>
+			<rect width="1" height="1" fill="none" stroke="#0f0"/>
+			<rect width="1" height="1" fill="none"/>
+		</g>`)
+	require.Len(t, svg.shapes, 4, "shapes without paint expected to be left out")
+
+	assert.Equal(t, color.RGBA{0, 0, 255, 128}, svg.shapes[0].fill)
+	assert.Equal(t, color.RGBA{255, 0, 0, 64}, svg.shapes[1].fill)
+	assert.Equal(t, color.RGBA{0, 0, 255, 64}, svg.shapes[2].fill, "unknown color expected to keep the inherited fill")
+	assert.False(t, svg.shapes[3].hasFill)
+	assert.True(t, svg.shapes[3].hasStroke)
+	assert.Equal(t, color.RGBA{0, 255, 0, 128}, svg.shapes[3].stroke)
+}
+
+func TestParseSVGErrors(t *testing.T) {
+	tt := []string{
+		``,
+		`<html></html>`,
+		`<svg><path d="M0 0L"/></svg>`,
+		`<svg><rect transform="spin(1)" width="1" height="1"/></svg>`,
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td, func(t *testing.T) {
+			_, err := ParseSVG([]byte(td))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func triangleArea(points []svgPoint) float64 {
+	area := 0.0
+	for i := 0; i+2 < len(points); i += 3 {
+		a, b, c := points[i], points[i+1], points[i+2]
+		area += abs64(b.sub(a).cross(c.sub(a))) / 2
+	}
+	return area
+}
+
+func abs64(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+func TestSVGFillRules(t *testing.T) {
+	const (
+		outer    = "M0 0H10V10H0Z"
+		inner    = "M3 3H7V7H3Z"
+		reversed = "M3 3V7H7V3Z"
+		apart    = "M20 0H24V4H20Z"
+	)
+	tt := []struct {
+		name string
+		body string
+		area float64
+		hole bool
+	}{
+		{name: "nonzero same winding", body: `<path d="` + outer + inner + `"/>`, area: 100},
+		{name: "nonzero opposite winding", body: `<path d="` + outer + reversed + `"/>`, area: 84, hole: true},
+		{name: "evenodd same winding", body: `<path fill-rule="evenodd" d="` + outer + inner + `"/>`, area: 84, hole: true},
+		{name: "evenodd opposite winding", body: `<path fill-rule="evenodd" d="` + outer + reversed + `"/>`, area: 84, hole: true},
+		{name: "separate polygons", body: `<path fill-rule="evenodd" d="` + outer + apart + `"/>`, area: 116},
+		{name: "concave", body: `<path d="M0 0H10V10H8V2H2V10H0Z"/>`, area: 52, hole: true},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			svg := parseTestSVG(t, td.body)
+			require.Len(t, svg.shapes, 1)
+
+			var points []svgPoint
+			for _, p := range svg.triangulatedFills(1)[0] {
+				points = append(points, svgPoint{float64(p.X), float64(p.Y)})
+			}
+			assert.InDelta(t, td.area, triangleArea(points), 1e-3)
+
+			img := svg.Rasterize(1)
+			assert.Equal(t, uint8(255), img.RGBAAt(1, 1).A, "filled pixel")
+			if td.hole {
+				assert.Equal(t, uint8(0), img.RGBAAt(4, 4).A, "pixel in the hole")
+			} else {
+				assert.Equal(t, uint8(255), img.RGBAAt(4, 4).A, "pixel in the inner polygon")
+			}
+		})
+	}
+}
+
+func TestSVGTriangulatedFillsCache(t *testing.T) {
+	svg := parseTestSVG(t, `<circle cx="5" cy="5" r="5"/>`)
+	fills := svg.triangulatedFills(2)
+	assert.Equal(t, &fills[0][0], &svg.triangulatedFills(2)[0][0], "triangulation expected to be cached")
+
+	large := svg.triangulatedFills(4)
+	assert.InEpsilon(t, 4*triangleAreaOfVec2s(fills[0]), triangleAreaOfVec2s(large[0]), 0.01)
+	assert.Equal(t, &fills[0][0], &svg.triangulatedFills(2)[0][0], "both scales expected to be cached")
+}
+
+func triangleAreaOfVec2s(points []imgui.Vec2) float64 {
+	var result []svgPoint
+	for _, p := range points {
+		result = append(result, svgPoint{float64(p.X), float64(p.Y)})
+	}
+	return triangleArea(result)
+}
+
+func TestSVGRasterize(t *testing.T) {
+	svg := parseTestSVG(t, `
+		<rect x="2" y="2" width="4" height="4" fill="#ff0000"/>
+		<rect x="6" y="2" width="2" height="2" fill="#0000ff" fill-opacity="0.5"/>
+		<line x1="0" y1="9" x2="10" y2="9" stroke="lime" stroke-width="2"/>`)
+
+	img := svg.Rasterize(1)
+	require.Equal(t, 10, img.Bounds().Dx())
+	require.Equal(t, 10, img.Bounds().Dy())
+
+	tt := []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{x: 0, y: 0, want: color.RGBA{}},
+		{x: 2, y: 2, want: color.RGBA{255, 0, 0, 255}},
+		{x: 5, y: 5, want: color.RGBA{255, 0, 0, 255}},
+		{x: 6, y: 6, want: color.RGBA{}},
+		// Translucent pixels are premultiplied.
+		{x: 7, y: 3, want: color.RGBA{0, 0, 128, 128}},
+		{x: 5, y: 8, want: color.RGBA{0, 255, 0, 255}},
+		{x: 5, y: 7, want: color.RGBA{}},
+	}
+	for _, tc := range tt {
+		assert.Equal(t, tc.want, img.RGBAAt(tc.x, tc.y), "pixel %d,%d", tc.x, tc.y)
+	}
+
+	// Edges of shapes between pixels are anti-aliased.
+	edge := parseTestSVG(t, `<rect x="0" y="0" width="4.5" height="4" fill="#fff"/>`).Rasterize(1)
+	assert.InDelta(t, 128, float64(edge.RGBAAt(4, 1).A), 2)
+
+	scaled := svg.Rasterize(2)
+	assert.Equal(t, 20, scaled.Bounds().Dx())
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, scaled.RGBAAt(11, 11))
+	assert.Equal(t, color.RGBA{}, scaled.RGBAAt(12, 12))
+}
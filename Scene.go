@@ -0,0 +1,903 @@
+package giu
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// ShapeKind tells what a Shape draws into its frame.
+type ShapeKind int
+
+const (
+	ShapeKindRect ShapeKind = iota
+	ShapeKindEllipse
+	ShapeKindPolyline
+	ShapeKindPath
+	ShapeKindImage
+	ShapeKindText
+)
+
+// Shape is an object of a Scene. Its geometry is fitted into the frame from Pos to Pos+Size,
+// which is rotated around its center, so moving and resizing only change Pos and Size.
+// Coordinates are relative to the upper left corner of the SceneView.
+type Shape struct {
+	Pos      imgui.Vec2
+	Size     imgui.Vec2
+	Rotation float32 // radians, clockwise
+	// Z orders the shapes, higher ones are drawn on top.
+	// Shapes with the same Z are stacked in the order they were added.
+	Z int
+	// Fill is the color of the inside of the shape, and the color of text.
+	Fill color.RGBA
+	// Stroke and Thickness outline the shape, a transparent Stroke draws no outline.
+	Stroke    color.RGBA
+	Thickness float32
+	// Locked shapes can be selected, but not moved, resized or rotated by the user.
+	Locked bool
+	// Data is left to the application, e.g. to link the shape to its model.
+	Data interface{}
+
+	kind ShapeKind
+
+	// Polyline and path geometry in unit coordinates of the frame, 0,0 is its upper left corner.
+	outlines  [][]imgui.Vec2
+	closed    []bool
+	triangles [][3]imgui.Vec2
+	evenOdd   bool
+
+	texture  *Texture
+	text     string
+	fontSize float32
+}
+
+var (
+	defaultShapeFill   = color.RGBA{200, 200, 200, 255}
+	defaultShapeStroke = color.RGBA{40, 40, 40, 255}
+)
+
+// NewRectShape creates a rectangle, filled light gray with a dark outline.
+func NewRectShape(pos, size imgui.Vec2) *Shape {
+	return &Shape{Pos: pos, Size: size, Fill: defaultShapeFill, Stroke: defaultShapeStroke, Thickness: 1, kind: ShapeKindRect}
+}
+
+// NewEllipseShape creates an ellipse inside the rectangle from pos to pos+size.
+func NewEllipseShape(pos, size imgui.Vec2) *Shape {
+	return &Shape{Pos: pos, Size: size, Fill: defaultShapeFill, Stroke: defaultShapeStroke, Thickness: 1, kind: ShapeKindEllipse}
+}
+
+// NewPolylineShape creates lines through points, closed polylines are filled too.
+func NewPolylineShape(points []imgui.Vec2, closed bool) *Shape {
+	poly := make([]svgPoint, len(points))
+	for i, p := range points {
+		poly[i] = svgPoint{float64(p.X), float64(p.Y)}
+	}
+
+	s := &Shape{Stroke: defaultShapeStroke, Thickness: 1, kind: ShapeKindPolyline}
+	if closed {
+		s.Fill = defaultShapeFill
+	}
+	s.setGeometry([][]svgPoint{poly}, []bool{closed}, false)
+	return s
+}
+
+// NewPathShape creates a shape from SVG path data, e.g. "M0 0 L10 0 Q20 10 10 20 Z".
+// Curves are flattened at their initial size.
+func NewPathShape(d string) (*Shape, error) {
+	var b svgPathBuilder
+	if err := b.parse(d); err != nil {
+		return nil, err
+	}
+
+	var polygons [][]svgPoint
+	var closed []bool
+	for _, sp := range b.subpaths {
+		if poly := cleanPolygon(sp.flatten(1, 0.5)); len(poly) >= 2 {
+			polygons = append(polygons, poly)
+			closed = append(closed, sp.closed)
+		}
+	}
+
+	s := &Shape{Fill: defaultShapeFill, Stroke: defaultShapeStroke, Thickness: 1, kind: ShapeKindPath}
+	s.setGeometry(polygons, closed, false)
+	return s, nil
+}
+
+// NewImageShape creates a shape showing texture in the rectangle from pos to pos+size.
+func NewImageShape(texture *Texture, pos, size imgui.Vec2) *Shape {
+	return &Shape{Pos: pos, Size: size, Fill: color.RGBA{255, 255, 255, 255}, kind: ShapeKindImage, texture: texture}
+}
+
+// NewTextShape creates a single or multi line text with its upper left corner at pos.
+// Its frame is measured with the default font when it's built first, resizing it scales the text.
+func NewTextShape(text string, pos imgui.Vec2, fontSize float32) *Shape {
+	return &Shape{Pos: pos, Fill: color.RGBA{255, 255, 255, 255}, kind: ShapeKindText, text: text, fontSize: fontSize}
+}
+
+// Kind returns what the shape draws.
+func (s *Shape) Kind() ShapeKind {
+	return s.kind
+}
+
+// Text returns the text of a text shape.
+func (s *Shape) Text() string {
+	return s.text
+}
+
+// SetText changes the text of a text shape, the frame is measured again.
+func (s *Shape) SetText(text string) {
+	s.text = text
+	s.Size = imgui.Vec2{}
+}
+
+// setGeometry fits polygons given in scene coordinates into the frame.
+func (s *Shape) setGeometry(polygons [][]svgPoint, closed []bool, evenOdd bool) {
+	min := svgPoint{math.Inf(1), math.Inf(1)}
+	max := svgPoint{math.Inf(-1), math.Inf(-1)}
+	for _, poly := range polygons {
+		for _, p := range poly {
+			min = svgPoint{math.Min(min.X, p.X), math.Min(min.Y, p.Y)}
+			max = svgPoint{math.Max(max.X, p.X), math.Max(max.Y, p.Y)}
+		}
+	}
+	if len(polygons) == 0 || math.IsInf(min.X, 1) {
+		return
+	}
+
+	s.Pos = imgui.Vec2{X: float32(min.X), Y: float32(min.Y)}
+	s.Size = imgui.Vec2{X: float32(max.X - min.X), Y: float32(max.Y - min.Y)}
+	s.closed = closed
+	s.evenOdd = evenOdd
+
+	unit := func(p svgPoint) imgui.Vec2 {
+		u := imgui.Vec2{X: 0.5, Y: 0.5}
+		if s.Size.X > 0 {
+			u.X = float32((p.X - min.X) / (max.X - min.X))
+		}
+		if s.Size.Y > 0 {
+			u.Y = float32((p.Y - min.Y) / (max.Y - min.Y))
+		}
+		return u
+	}
+
+	var fill [][]svgPoint
+	for i, poly := range polygons {
+		outline := make([]imgui.Vec2, len(poly))
+		for j, p := range poly {
+			outline[j] = unit(p)
+		}
+		s.outlines = append(s.outlines, outline)
+		if closed[i] && len(poly) >= 3 {
+			fill = append(fill, poly)
+		}
+	}
+
+	for _, poly := range groupPolygons(fill, evenOdd) {
+		for _, tri := range triangulate(poly) {
+			s.triangles = append(s.triangles, [3]imgui.Vec2{unit(tri[0]), unit(tri[1]), unit(tri[2])})
+		}
+	}
+}
+
+func (s *Shape) center() imgui.Vec2 {
+	return s.Pos.Plus(s.Size.Times(0.5))
+}
+
+// toScene maps a point relative to the center of the unrotated frame into scene coordinates.
+func (s *Shape) toScene(local imgui.Vec2) imgui.Vec2 {
+	return s.center().Plus(rotateVec2(local, s.Rotation))
+}
+
+// toLocal maps a point in scene coordinates to the center of the unrotated frame.
+func (s *Shape) toLocal(p imgui.Vec2) imgui.Vec2 {
+	return rotateVec2(p.Minus(s.center()), -s.Rotation)
+}
+
+func (s *Shape) unitToScene(u imgui.Vec2) imgui.Vec2 {
+	return s.toScene(imgui.Vec2{X: (u.X - 0.5) * s.Size.X, Y: (u.Y - 0.5) * s.Size.Y})
+}
+
+// Corners returns the corners of the rotated frame, clockwise from the upper left one.
+func (s *Shape) Corners() [4]imgui.Vec2 {
+	half := s.Size.Times(0.5)
+	return [4]imgui.Vec2{
+		s.toScene(imgui.Vec2{X: -half.X, Y: -half.Y}),
+		s.toScene(imgui.Vec2{X: half.X, Y: -half.Y}),
+		s.toScene(imgui.Vec2{X: half.X, Y: half.Y}),
+		s.toScene(imgui.Vec2{X: -half.X, Y: half.Y}),
+	}
+}
+
+// Bounds returns the axis aligned rectangle around the rotated frame.
+func (s *Shape) Bounds() (min, max imgui.Vec2) {
+	corners := s.Corners()
+	min, max = corners[0], corners[0]
+	for _, c := range corners[1:] {
+		min = imgui.Vec2{X: minf(min.X, c.X), Y: minf(min.Y, c.Y)}
+		max = imgui.Vec2{X: maxf(max.X, c.X), Y: maxf(max.Y, c.Y)}
+	}
+	return min, max
+}
+
+// sceneHitTolerance is how many pixels the mouse may miss a shape or handle by.
+const sceneHitTolerance = 4
+
+// Contains reports whether p, in scene coordinates, hits the shape.
+// Edges are hit within a few pixels, the inside of polylines and paths only if they are filled.
+func (s *Shape) Contains(p imgui.Vec2) bool {
+	l := s.toLocal(p)
+	half := s.Size.Times(0.5)
+	tol := float32(sceneHitTolerance) + s.Thickness/2
+
+	switch s.kind {
+	case ShapeKindEllipse:
+		rx, ry := half.X+tol, half.Y+tol
+		return (l.X*l.X)/(rx*rx)+(l.Y*l.Y)/(ry*ry) <= 1
+	case ShapeKindPolyline, ShapeKindPath:
+		lp := svgPoint{float64(l.X), float64(l.Y)}
+		var polygons [][]svgPoint
+		for i, outline := range s.outlines {
+			poly := make([]svgPoint, len(outline))
+			for j, u := range outline {
+				poly[j] = svgPoint{float64((u.X - 0.5) * s.Size.X), float64((u.Y - 0.5) * s.Size.Y)}
+			}
+			if distanceToPolyline(lp, poly, s.closed[i]) <= float64(tol) {
+				return true
+			}
+			if s.closed[i] {
+				polygons = append(polygons, poly)
+			}
+		}
+		return s.Fill.A > 0 && insidePolygons(lp, polygons, s.evenOdd)
+	default:
+		return l.X >= -half.X-tol && l.X <= half.X+tol && l.Y >= -half.Y-tol && l.Y <= half.Y+tol
+	}
+}
+
+// draw draws the shape with the scene origin at origin.
+func (s *Shape) draw(c *Canvas, origin imgui.Vec2) {
+	toScreen := func(u imgui.Vec2) imgui.Vec2 {
+		return origin.Plus(s.unitToScene(u))
+	}
+	stroke := s.Stroke.A > 0 && s.Thickness > 0
+
+	switch s.kind {
+	case ShapeKindRect, ShapeKindImage:
+		corners := s.Corners()
+		for i := range corners {
+			corners[i] = origin.Plus(corners[i])
+		}
+		if s.kind == ShapeKindImage {
+			c.AddImageQuadF(s.texture, corners[0], corners[1], corners[2], corners[3],
+				imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 0}, imgui.Vec2{X: 1, Y: 1}, imgui.Vec2{X: 0, Y: 1}, s.Fill)
+		} else if s.Fill.A > 0 {
+			c.AddQuadFilledF(corners[0], corners[1], corners[2], corners[3], s.Fill)
+		}
+		if stroke {
+			c.AddQuadF(corners[0], corners[1], corners[2], corners[3], s.Stroke, s.Thickness)
+		}
+	case ShapeKindEllipse:
+		segments := int(math.Max(12, math.Min(64, float64(maxf(s.Size.X, s.Size.Y))/4)))
+		points := make([]imgui.Vec2, segments)
+		for i := range points {
+			sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(segments))
+			points[i] = toScreen(imgui.Vec2{X: 0.5 + float32(cos)/2, Y: 0.5 + float32(sin)/2})
+		}
+		if s.Fill.A > 0 {
+			c.AddConvexPolyFilledF(points, s.Fill)
+		}
+		if stroke {
+			c.AddPolylineF(points, s.Stroke, true, s.Thickness)
+		}
+	case ShapeKindPolyline, ShapeKindPath:
+		if s.Fill.A > 0 && len(s.triangles) > 0 {
+			// One mesh, anti-aliased triangles leave seams between each other.
+			points := make([]imgui.Vec2, 0, 3*len(s.triangles))
+			for _, tri := range s.triangles {
+				points = append(points, toScreen(tri[0]), toScreen(tri[1]), toScreen(tri[2]))
+			}
+			c.drawlist.AddTriangles(points, ToVec4Color(s.Fill))
+		}
+		if stroke {
+			for i, outline := range s.outlines {
+				points := make([]imgui.Vec2, len(outline))
+				for j, u := range outline {
+					points[j] = toScreen(u)
+				}
+				c.AddPolylineF(points, s.Stroke, s.closed[i], s.Thickness)
+			}
+		}
+	case ShapeKindText:
+		lineHeight := imgui.CalcTextSize("", false, 0).Y
+		size := imgui.CalcTextSize(s.text, false, 0)
+		if s.Size.X <= 0 || s.Size.Y <= 0 {
+			s.Size = size.Times(s.fontSize / lineHeight)
+		}
+		if size.Y > 0 {
+			fontSize := s.Size.Y / size.Y * lineHeight
+			c.AddTextRotatedF(nil, fontSize, toScreen(imgui.Vec2{}), s.Rotation, s.Fill, s.text)
+		}
+		if stroke {
+			corners := s.Corners()
+			c.AddQuadF(origin.Plus(corners[0]), origin.Plus(corners[1]), origin.Plus(corners[2]), origin.Plus(corners[3]), s.Stroke, s.Thickness)
+		}
+	}
+}
+
+func rotateVec2(v imgui.Vec2, angle float32) imgui.Vec2 {
+	if angle == 0 {
+		return v
+	}
+	sin, cos := math.Sincos(float64(angle))
+	return imgui.Vec2{
+		X: v.X*float32(cos) - v.Y*float32(sin),
+		Y: v.X*float32(sin) + v.Y*float32(cos),
+	}
+}
+
+func distanceToPolyline(p svgPoint, poly []svgPoint, closed bool) float64 {
+	dist := math.Inf(1)
+	n := len(poly)
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	for i := 0; i < segments; i++ {
+		a, b := poly[i], poly[(i+1)%n]
+		ab := b.sub(a)
+		t := 0.0
+		if l := ab.X*ab.X + ab.Y*ab.Y; l > 0 {
+			t = math.Max(0, math.Min(1, ((p.X-a.X)*ab.X+(p.Y-a.Y)*ab.Y)/l))
+		}
+		dist = math.Min(dist, p.dist(a.add(ab.mul(t))))
+	}
+	if n == 1 {
+		dist = p.dist(poly[0])
+	}
+	return dist
+}
+
+func insidePolygons(p svgPoint, polygons [][]svgPoint, evenOdd bool) bool {
+	winding := 0
+	for _, poly := range polygons {
+		for i := range poly {
+			a, b := poly[i], poly[(i+1)%len(poly)]
+			if a.Y <= p.Y && b.Y > p.Y && b.sub(a).cross(p.sub(a)) > 0 {
+				winding++
+			} else if a.Y > p.Y && b.Y <= p.Y && b.sub(a).cross(p.sub(a)) < 0 {
+				winding--
+			}
+		}
+	}
+	if evenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// SceneChange tells how the user changed shapes.
+type SceneChange int
+
+const (
+	SceneChangeMove SceneChange = iota
+	SceneChangeResize
+	SceneChangeRotate
+)
+
+type sceneAction int
+
+const (
+	sceneActionNone sceneAction = iota
+	sceneActionMove
+	sceneActionResize
+	sceneActionRotate
+	sceneActionSelect
+)
+
+// Handles of a selected shape, as the signs of their position in its frame.
+var sceneHandles = [8][2]float32{{-1, -1}, {0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}}
+
+const (
+	sceneHandleSize      = 6
+	sceneRotateHandle    = len(sceneHandles)
+	sceneRotateDistance  = 20
+	sceneRotateSnapAngle = math.Pi / 12
+)
+
+var (
+	sceneSelectionColor = color.RGBA{66, 150, 250, 255}
+	sceneHoverColor     = color.RGBA{66, 150, 250, 128}
+	sceneBandFill       = color.RGBA{66, 150, 250, 40}
+)
+
+type shapeFrame struct {
+	pos, size imgui.Vec2
+	rotation  float32
+}
+
+// Scene keeps shapes with their selection, and lets the user select, move, resize and rotate them
+// with a SceneView. Clicking selects a shape, ctrl or shift click toggles it, dragging on the background
+// selects the shapes within the rubber band. Resizing with shift held keeps the aspect ratio,
+// rotating with shift held snaps to 15 degrees.
+type Scene struct {
+	shapes   []*Shape
+	selected map[*Shape]bool
+	hovered  *Shape
+
+	onChange    func(shapes []*Shape, change SceneChange)
+	onSelection func(selection []*Shape)
+
+	action     sceneAction
+	handle     int
+	dragStart  imgui.Vec2
+	dragMouse  imgui.Vec2
+	additive   bool
+	startFrame map[*Shape]shapeFrame
+	changed    bool
+}
+
+// NewScene creates an empty scene.
+func NewScene() *Scene {
+	return &Scene{selected: make(map[*Shape]bool)}
+}
+
+// SetOnChange sets the callback invoked when the user finished moving, resizing or rotating shapes.
+func (s *Scene) SetOnChange(onChange func(shapes []*Shape, change SceneChange)) {
+	s.onChange = onChange
+}
+
+// SetOnSelectionChange sets the callback invoked when the user changed the selection.
+func (s *Scene) SetOnSelectionChange(onSelection func(selection []*Shape)) {
+	s.onSelection = onSelection
+}
+
+// Add adds shapes to the scene, shapes already in it are skipped.
+func (s *Scene) Add(shapes ...*Shape) {
+	for _, shape := range shapes {
+		if shape != nil && s.indexOf(shape) < 0 {
+			s.shapes = append(s.shapes, shape)
+		}
+	}
+}
+
+// Remove removes shapes from the scene and the selection.
+func (s *Scene) Remove(shapes ...*Shape) {
+	for _, shape := range shapes {
+		if i := s.indexOf(shape); i >= 0 {
+			s.shapes = append(s.shapes[:i], s.shapes[i+1:]...)
+		}
+		delete(s.selected, shape)
+		if s.hovered == shape {
+			s.hovered = nil
+		}
+	}
+}
+
+// Clear removes all shapes.
+func (s *Scene) Clear() {
+	s.shapes = nil
+	s.selected = make(map[*Shape]bool)
+	s.hovered = nil
+	s.action = sceneActionNone
+}
+
+func (s *Scene) indexOf(shape *Shape) int {
+	for i, sh := range s.shapes {
+		if sh == shape {
+			return i
+		}
+	}
+	return -1
+}
+
+// Shapes returns the shapes from the bottom to the top.
+func (s *Scene) Shapes() []*Shape {
+	s.sort()
+	return append([]*Shape(nil), s.shapes...)
+}
+
+func (s *Scene) sort() {
+	sort.SliceStable(s.shapes, func(i, j int) bool { return s.shapes[i].Z < s.shapes[j].Z })
+}
+
+// BringToFront moves shape above all others.
+func (s *Scene) BringToFront(shape *Shape) {
+	for _, sh := range s.shapes {
+		if sh != shape && sh.Z >= shape.Z {
+			shape.Z = sh.Z + 1
+		}
+	}
+}
+
+// SendToBack moves shape below all others.
+func (s *Scene) SendToBack(shape *Shape) {
+	for _, sh := range s.shapes {
+		if sh != shape && sh.Z <= shape.Z {
+			shape.Z = sh.Z - 1
+		}
+	}
+}
+
+// ShapeAt returns the topmost shape at p, in scene coordinates, or nil.
+func (s *Scene) ShapeAt(p imgui.Vec2) *Shape {
+	s.sort()
+	for i := len(s.shapes) - 1; i >= 0; i-- {
+		if s.shapes[i].Contains(p) {
+			return s.shapes[i]
+		}
+	}
+	return nil
+}
+
+// Hovered returns the shape under the mouse cursor, or nil.
+func (s *Scene) Hovered() *Shape {
+	return s.hovered
+}
+
+// IsSelected reports whether shape is selected.
+func (s *Scene) IsSelected(shape *Shape) bool {
+	return s.selected[shape]
+}
+
+// Selection returns the selected shapes from the bottom to the top.
+func (s *Scene) Selection() []*Shape {
+	s.sort()
+	var selection []*Shape
+	for _, shape := range s.shapes {
+		if s.selected[shape] {
+			selection = append(selection, shape)
+		}
+	}
+	return selection
+}
+
+// Select replaces the selection with shapes, the selection callback isn't invoked.
+func (s *Scene) Select(shapes ...*Shape) {
+	s.selected = make(map[*Shape]bool)
+	for _, shape := range shapes {
+		if s.indexOf(shape) >= 0 {
+			s.selected[shape] = true
+		}
+	}
+}
+
+// handleAt returns the handle of the only selected shape at p, or -1.
+func (s *Scene) handleAt(p imgui.Vec2) int {
+	shape := s.single()
+	if shape == nil || shape.Locked {
+		return -1
+	}
+	for i := 0; i <= sceneRotateHandle; i++ {
+		if h := sceneHandlePos(shape, i); math.Abs(float64(p.X-h.X)) <= sceneHandleSize && math.Abs(float64(p.Y-h.Y)) <= sceneHandleSize {
+			return i
+		}
+	}
+	return -1
+}
+
+// single returns the selected shape if exactly one is selected.
+func (s *Scene) single() *Shape {
+	if len(s.selected) != 1 {
+		return nil
+	}
+	for shape := range s.selected {
+		return shape
+	}
+	return nil
+}
+
+func sceneHandlePos(shape *Shape, handle int) imgui.Vec2 {
+	half := shape.Size.Times(0.5)
+	if handle == sceneRotateHandle {
+		return shape.toScene(imgui.Vec2{X: 0, Y: -half.Y - sceneRotateDistance})
+	}
+	h := sceneHandles[handle]
+	return shape.toScene(imgui.Vec2{X: h[0] * half.X, Y: h[1] * half.Y})
+}
+
+func (s *Scene) press(mouse imgui.Vec2, additive bool) {
+	s.dragStart = mouse
+	s.additive = additive
+	s.changed = false
+
+	if handle := s.handleAt(mouse); handle >= 0 {
+		s.handle = handle
+		s.action = sceneActionResize
+		if handle == sceneRotateHandle {
+			s.action = sceneActionRotate
+		}
+		s.saveFrames()
+		return
+	}
+
+	shape := s.ShapeAt(mouse)
+	if shape == nil {
+		s.action = sceneActionSelect
+		return
+	}
+
+	selectionChanged := false
+	switch {
+	case additive:
+		if s.selected[shape] {
+			delete(s.selected, shape)
+		} else {
+			s.selected[shape] = true
+		}
+		selectionChanged = true
+	case !s.selected[shape]:
+		s.selected = map[*Shape]bool{shape: true}
+		selectionChanged = true
+	}
+	if selectionChanged && s.onSelection != nil {
+		s.onSelection(s.Selection())
+	}
+
+	s.action = sceneActionNone
+	if s.selected[shape] {
+		s.action = sceneActionMove
+		s.saveFrames()
+	}
+}
+
+func (s *Scene) saveFrames() {
+	s.startFrame = make(map[*Shape]shapeFrame, len(s.selected))
+	for shape := range s.selected {
+		s.startFrame[shape] = shapeFrame{shape.Pos, shape.Size, shape.Rotation}
+	}
+}
+
+func (s *Scene) drag(mouse imgui.Vec2, shift bool) {
+	s.dragMouse = mouse
+	if mouse == s.dragStart && !s.changed {
+		return
+	}
+
+	switch s.action {
+	case sceneActionMove:
+		delta := mouse.Minus(s.dragStart)
+		for shape, frame := range s.startFrame {
+			if !shape.Locked {
+				shape.Pos = frame.pos.Plus(delta)
+			}
+		}
+	case sceneActionResize:
+		if shape := s.single(); shape != nil {
+			s.resize(shape, s.startFrame[shape], mouse, shift || shape.kind == ShapeKindText)
+		}
+	case sceneActionRotate:
+		if shape := s.single(); shape != nil {
+			frame := s.startFrame[shape]
+			center := frame.pos.Plus(frame.size.Times(0.5))
+			from := math.Atan2(float64(s.dragStart.Y-center.Y), float64(s.dragStart.X-center.X))
+			to := math.Atan2(float64(mouse.Y-center.Y), float64(mouse.X-center.X))
+			angle := float64(frame.rotation) + to - from
+			if shift {
+				angle = math.Round(angle/sceneRotateSnapAngle) * sceneRotateSnapAngle
+			}
+			shape.Rotation = float32(math.Remainder(angle, 2*math.Pi))
+		}
+	}
+	s.changed = true
+}
+
+// resize moves the dragged handle of the frame to mouse, keeping the opposite side in place.
+func (s *Scene) resize(shape *Shape, frame shapeFrame, mouse imgui.Vec2, keepAspect bool) {
+	center := frame.pos.Plus(frame.size.Times(0.5))
+	l := rotateVec2(mouse.Minus(center), -frame.rotation)
+	h := sceneHandles[s.handle]
+	min, max := frame.size.Times(-0.5), frame.size.Times(0.5)
+
+	// Size along each axis, the handle's side is dragged and the opposite one stays.
+	size := frame.size
+	if h[0] < 0 {
+		size.X = max.X - l.X
+	} else if h[0] > 0 {
+		size.X = l.X - min.X
+	}
+	if h[1] < 0 {
+		size.Y = max.Y - l.Y
+	} else if h[1] > 0 {
+		size.Y = l.Y - min.Y
+	}
+	size = imgui.Vec2{X: maxf(1, size.X), Y: maxf(1, size.Y)}
+
+	if keepAspect && frame.size.X > 0 && frame.size.Y > 0 {
+		scale := maxf(size.X/frame.size.X, size.Y/frame.size.Y)
+		if h[0] == 0 {
+			scale = size.Y / frame.size.Y
+		} else if h[1] == 0 {
+			scale = size.X / frame.size.X
+		}
+		size = frame.size.Times(scale)
+	}
+
+	// Place the new frame against the side that stays.
+	newMin, newMax := size.Times(-0.5), size.Times(0.5)
+	offset := imgui.Vec2{}
+	if h[0] < 0 {
+		offset.X = max.X - newMax.X
+	} else if h[0] > 0 {
+		offset.X = min.X - newMin.X
+	}
+	if h[1] < 0 {
+		offset.Y = max.Y - newMax.Y
+	} else if h[1] > 0 {
+		offset.Y = min.Y - newMin.Y
+	}
+
+	newCenter := center.Plus(rotateVec2(offset, frame.rotation))
+	shape.Size = size
+	shape.Pos = newCenter.Minus(size.Times(0.5))
+}
+
+func (s *Scene) release() {
+	switch s.action {
+	case sceneActionMove, sceneActionResize, sceneActionRotate:
+		if s.changed && s.onChange != nil {
+			change := SceneChangeMove
+			if s.action == sceneActionResize {
+				change = SceneChangeResize
+			} else if s.action == sceneActionRotate {
+				change = SceneChangeRotate
+			}
+			s.onChange(s.Selection(), change)
+		}
+	case sceneActionSelect:
+		bandMin, bandMax := s.band()
+		selection := make(map[*Shape]bool)
+		if s.additive {
+			for shape := range s.selected {
+				selection[shape] = true
+			}
+		}
+		for _, shape := range s.shapes {
+			min, max := shape.Bounds()
+			if s.changed && min.X >= bandMin.X && min.Y >= bandMin.Y && max.X <= bandMax.X && max.Y <= bandMax.Y {
+				selection[shape] = true
+			}
+		}
+		if !sameSelection(selection, s.selected) {
+			s.selected = selection
+			if s.onSelection != nil {
+				s.onSelection(s.Selection())
+			}
+		}
+	}
+	s.action = sceneActionNone
+	s.startFrame = nil
+}
+
+func (s *Scene) band() (min, max imgui.Vec2) {
+	return imgui.Vec2{X: minf(s.dragStart.X, s.dragMouse.X), Y: minf(s.dragStart.Y, s.dragMouse.Y)},
+		imgui.Vec2{X: maxf(s.dragStart.X, s.dragMouse.X), Y: maxf(s.dragStart.Y, s.dragMouse.Y)}
+}
+
+func sameSelection(a, b map[*Shape]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for shape := range a {
+		if !b[shape] {
+			return false
+		}
+	}
+	return true
+}
+
+type SceneViewWidget struct {
+	id     string
+	scene  *Scene
+	width  float32
+	height float32
+}
+
+// SceneView draws scene and handles mouse input for it. Width or height -1 fill the available space.
+// Shape coordinates are relative to the upper left corner of the view.
+func SceneView(id string, scene *Scene, width, height float32) *SceneViewWidget {
+	return &SceneViewWidget{
+		id:     id,
+		scene:  scene,
+		width:  width,
+		height: height,
+	}
+}
+
+func (v *SceneViewWidget) Build() {
+	size := imgui.Vec2{X: v.width, Y: v.height}
+	avail := imgui.ContentRegionAvail()
+	if size.X == -1 {
+		size.X = avail.X
+	}
+	if size.Y == -1 {
+		size.Y = avail.Y
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+
+	origin := imgui.CursorScreenPos()
+	imgui.InvisibleButton(v.id, size)
+	if v.scene == nil {
+		return
+	}
+
+	s := v.scene
+	io := Context.IO()
+	mouse := imgui.MousePos().Minus(origin)
+	hovered := imgui.IsItemHovered()
+
+	if hovered && imgui.IsMouseClicked(0) {
+		s.press(mouse, io.KeyCtrlPressed() || io.KeyShiftPressed())
+	}
+	if s.action != sceneActionNone {
+		if imgui.IsItemActive() {
+			s.drag(mouse, io.KeyShiftPressed())
+		} else {
+			s.release()
+		}
+	}
+
+	s.hovered = nil
+	handle := -1
+	if hovered && s.action == sceneActionNone {
+		if handle = s.handleAt(mouse); handle < 0 {
+			s.hovered = s.ShapeAt(mouse)
+		}
+	}
+	switch {
+	case handle == sceneRotateHandle || s.action == sceneActionRotate:
+		imgui.SetMouseCursor(imgui.MouseCursorHand)
+	case handle >= 0 || s.action == sceneActionResize:
+		imgui.SetMouseCursor(imgui.MouseCursorResizeAll)
+	}
+
+	s.sort()
+	canvas := GetCanvas()
+	canvas.PushClipRectF(origin, origin.Plus(size), true)
+	defer canvas.PopClipRect()
+
+	for _, shape := range s.shapes {
+		shape.draw(canvas, origin)
+	}
+
+	outline := func(shape *Shape, col color.RGBA) {
+		corners := shape.Corners()
+		points := make([]imgui.Vec2, len(corners))
+		for i, c := range corners {
+			points[i] = origin.Plus(c)
+		}
+		canvas.AddPolylineF(points, col, true, 1)
+	}
+
+	if s.hovered != nil && !s.selected[s.hovered] {
+		outline(s.hovered, sceneHoverColor)
+	}
+	for _, shape := range s.shapes {
+		if s.selected[shape] {
+			outline(shape, sceneSelectionColor)
+		}
+	}
+
+	if shape := s.single(); shape != nil && !shape.Locked {
+		top := origin.Plus(sceneHandlePos(shape, 1))
+		rotate := origin.Plus(sceneHandlePos(shape, sceneRotateHandle))
+		canvas.AddLineF(top, rotate, sceneSelectionColor, 1)
+		canvas.AddCircleFilledF(rotate, sceneHandleSize/2+1, sceneSelectionColor, 12)
+
+		half := imgui.Vec2{X: sceneHandleSize / 2, Y: sceneHandleSize / 2}
+		for i := range sceneHandles {
+			p := origin.Plus(sceneHandlePos(shape, i))
+			canvas.AddRectFilledF(p.Minus(half), p.Plus(half), color.RGBA{255, 255, 255, 255}, 0, CornerFlags_None)
+			canvas.AddRectF(p.Minus(half), p.Plus(half), sceneSelectionColor, 0, CornerFlags_None, 1)
+		}
+	}
+
+	if s.action == sceneActionSelect && s.changed {
+		min, max := s.band()
+		canvas.AddRectFilledF(origin.Plus(min), origin.Plus(max), sceneBandFill, 0, CornerFlags_None)
+		canvas.AddRectF(origin.Plus(min), origin.Plus(max), sceneSelectionColor, 0, CornerFlags_None, 1)
+	}
+}
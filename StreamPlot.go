@@ -0,0 +1,276 @@
+package giu
+
+import (
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// streamUpdateInterval limits how often samples redraw the window.
+const streamUpdateInterval = time.Second / 30
+
+var throttledUpdate struct {
+	mu      sync.Mutex
+	pending bool
+}
+
+// updateThrottled calls Update once streamUpdateInterval passed, calls in between are merged into it.
+func updateThrottled() {
+	throttledUpdate.mu.Lock()
+	defer throttledUpdate.mu.Unlock()
+	if throttledUpdate.pending {
+		return
+	}
+	throttledUpdate.pending = true
+
+	time.AfterFunc(streamUpdateInterval, func() {
+		throttledUpdate.mu.Lock()
+		throttledUpdate.pending = false
+		throttledUpdate.mu.Unlock()
+
+		if Context.platform != nil {
+			Update()
+		}
+	})
+}
+
+// PlotRingBuffer keeps the latest samples of a time series, older ones are overwritten when it's full.
+// It's safe to add samples from any go routine, the window is redrawn at a limited rate.
+type PlotRingBuffer struct {
+	mu     sync.Mutex
+	times  []int64 // unix nanoseconds
+	values []float64
+	start  int
+	count  int
+}
+
+// NewPlotRingBuffer creates a buffer for capacity samples.
+func NewPlotRingBuffer(capacity int) *PlotRingBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &PlotRingBuffer{
+		times:  make([]int64, capacity),
+		values: make([]float64, capacity),
+	}
+}
+
+// Add appends a sample taken at t. Samples are expected in chronological order.
+func (b *PlotRingBuffer) Add(t time.Time, value float64) {
+	b.mu.Lock()
+	i := (b.start + b.count) % len(b.times)
+	b.times[i] = t.UnixNano()
+	b.values[i] = value
+	if b.count < len(b.times) {
+		b.count++
+	} else {
+		b.start = (b.start + 1) % len(b.times)
+	}
+	b.mu.Unlock()
+
+	updateThrottled()
+}
+
+// AddNow appends a sample taken now.
+func (b *PlotRingBuffer) AddNow(value float64) {
+	b.Add(time.Now(), value)
+}
+
+// Len returns the count of samples in the buffer.
+func (b *PlotRingBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count
+}
+
+// Clear removes all samples.
+func (b *PlotRingBuffer) Clear() {
+	b.mu.Lock()
+	b.start, b.count = 0, 0
+	b.mu.Unlock()
+}
+
+// window returns the samples between from and to, with x in seconds relative to to.
+// The last sample before from is included so lines enter the plot from its edge.
+// Samples are decimated into buckets, keeping the minimum and maximum of each.
+func (b *PlotRingBuffer) window(from, to int64, buckets int) (xs, ys []float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if buckets < 1 {
+		buckets = 1
+	}
+	span := float64(to - from)
+	bucket := -1
+	minN, maxN := -1, -1 // positions of the extremes in the current bucket, oldest sample first
+	at := func(n int) int { return (b.start + n) % len(b.times) }
+
+	emit := func(n int) {
+		xs = append(xs, float64(b.times[at(n)]-to)/1e9)
+		ys = append(ys, b.values[at(n)])
+	}
+	flush := func() {
+		if minN < 0 {
+			return
+		}
+		first, second := minN, maxN
+		if first > second {
+			first, second = second, first
+		}
+		emit(first)
+		if second != first {
+			emit(second)
+		}
+		minN, maxN = -1, -1
+	}
+
+	for n := 0; n < b.count; n++ {
+		t := b.times[at(n)]
+		if t > to {
+			break
+		}
+		if t < from {
+			// Only the last sample before the window is kept.
+			if n+1 == b.count || b.times[at(n+1)] >= from {
+				emit(n)
+			}
+			continue
+		}
+
+		// An empty window keeps everything in one bucket instead of dividing by zero.
+		k := 0
+		if span > 0 {
+			k = int(math.Min(float64(t-from)/span*float64(buckets), float64(buckets-1)))
+		}
+		if k != bucket {
+			flush()
+			bucket = k
+		}
+		v := b.values[at(n)]
+		if minN < 0 || v < b.values[at(minN)] {
+			minN = n
+		}
+		if maxN < 0 || v > b.values[at(maxN)] {
+			maxN = n
+		}
+	}
+	flush()
+
+	return xs, ys
+}
+
+// StreamSeries shows a PlotRingBuffer in a StreamPlot, a zero Color picks one from the default palette.
+type StreamSeries struct {
+	Label     string
+	Type      PlotSeriesType
+	Buffer    *PlotRingBuffer
+	Color     color.RGBA
+	Thickness float32
+}
+
+// PlotStream creates a line series of the samples in buffer.
+func PlotStream(label string, buffer *PlotRingBuffer) *StreamSeries {
+	return &StreamSeries{Label: label, Type: PlotSeriesLine, Buffer: buffer, Thickness: 1}
+}
+
+// StreamPlotState keeps the zoom and the hidden series of a StreamPlot between frames.
+type StreamPlotState struct {
+	plot     PlotState
+	hovered  bool
+	pausedAt time.Time
+}
+
+// Plot returns the state of the underlying plot, e.g. to hide series.
+func (s *StreamPlotState) Plot() *PlotState {
+	return &s.plot
+}
+
+// Paused reports whether the plot stopped scrolling because the mouse is over it.
+func (s *StreamPlotState) Paused() bool {
+	return s.hovered
+}
+
+type StreamPlotWidget struct {
+	id     string
+	state  *StreamPlotState
+	width  float32
+	height float32
+	window time.Duration
+	title  string
+	yLabel string
+	series []*StreamSeries
+}
+
+// StreamPlot shows the samples of the last window up to now, scrolling as time passes,
+// and stops scrolling while the mouse is over it to inspect the samples. The x axis is in seconds relative to now.
+// Width or height -1 fill the available space.
+func StreamPlot(id string, state *StreamPlotState, width, height float32, window time.Duration, series ...*StreamSeries) *StreamPlotWidget {
+	return StreamPlotV(id, state, width, height, window, "", "", series...)
+}
+
+// StreamPlotV works like StreamPlot with a title and a y axis label.
+func StreamPlotV(id string, state *StreamPlotState, width, height float32, window time.Duration, title, yLabel string, series ...*StreamSeries) *StreamPlotWidget {
+	return &StreamPlotWidget{
+		id:     id,
+		state:  state,
+		width:  width,
+		height: height,
+		window: window,
+		title:  title,
+		yLabel: yLabel,
+		series: series,
+	}
+}
+
+func (p *StreamPlotWidget) Build() {
+	s := p.state
+	if s == nil {
+		return
+	}
+
+	now := time.Now()
+	if s.hovered {
+		now = s.pausedAt
+	} else {
+		s.pausedAt = now
+		// Keep scrolling without new samples.
+		imgui.SetMaxWaitBeforeNextFrame(float32(streamUpdateInterval.Seconds()))
+	}
+
+	width := p.width
+	if width == -1 {
+		width = imgui.ContentRegionAvail().X
+	}
+
+	to := now.UnixNano()
+	from := now.Add(-p.window).UnixNano()
+	series := make([]*PlotSeries, len(p.series))
+	for i, ss := range p.series {
+		xs, ys := []float64{}, []float64{}
+		if ss.Buffer != nil {
+			xs, ys = ss.Buffer.window(from, to, int(math.Max(1, float64(width))))
+		}
+		series[i] = &PlotSeries{
+			Label:      ss.Label,
+			Type:       ss.Type,
+			X:          xs,
+			Y:          ys,
+			Color:      ss.Color,
+			Thickness:  ss.Thickness,
+			MarkerSize: 2,
+		}
+	}
+
+	if !s.plot.userView {
+		s.plot.xMin, s.plot.xMax = -p.window.Seconds(), 0
+	}
+
+	plot := PlotV(p.id, &s.plot, p.width, p.height, p.title, "", p.yLabel, PlotAxisLinear, PlotAxisLinear, true, series...)
+	plot.fixedX = true
+	plot.Build()
+
+	s.hovered = imgui.IsItemHovered()
+}
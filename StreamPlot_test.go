@@ -0,0 +1,128 @@
+package giu
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seconds(n int64) int64 {
+	return time.Unix(n, 0).UnixNano()
+}
+
+func newTestRingBuffer(capacity int, values ...float64) *PlotRingBuffer {
+	b := NewPlotRingBuffer(capacity)
+	for i, v := range values {
+		b.Add(time.Unix(int64(i), 0), v)
+	}
+	return b
+}
+
+func TestPlotRingBufferWindow(t *testing.T) {
+	tt := []struct {
+		name     string
+		buffer   *PlotRingBuffer
+		from, to int64
+		buckets  int
+		xs, ys   []float64
+	}{
+		{
+			name:   "wraparound",
+			buffer: newTestRingBuffer(4, 0, 1, 2, 3, 4, 5),
+			from:   seconds(0), to: seconds(5), buckets: 100,
+			xs: []float64{-3, -2, -1, 0}, ys: []float64{2, 3, 4, 5},
+		},
+		{
+			name:   "sample before the window",
+			buffer: newTestRingBuffer(4, 0, 1, 2, 3, 4, 5),
+			from:   seconds(4) + 1, to: seconds(5), buckets: 100,
+			xs: []float64{-1, 0}, ys: []float64{4, 5},
+		},
+		{
+			name:   "samples after the window",
+			buffer: newTestRingBuffer(8, 0, 1, 2, 3, 4, 5),
+			from:   seconds(1), to: seconds(3), buckets: 100,
+			xs: []float64{-3, -2, -1, 0}, ys: []float64{0, 1, 2, 3},
+		},
+		{
+			// Each bucket keeps its minimum and maximum, in the order they were added.
+			name:   "decimation",
+			buffer: newTestRingBuffer(16, 5, 1, 9, 3, 4, 2, 8, 0, 7, 6),
+			from:   seconds(0), to: seconds(10), buckets: 2,
+			xs: []float64{-9, -8, -4, -3}, ys: []float64{1, 9, 8, 0},
+		},
+		{
+			name:   "constant bucket",
+			buffer: newTestRingBuffer(16, 1, 1, 1, 1),
+			from:   seconds(0), to: seconds(4), buckets: 1,
+			xs: []float64{-4}, ys: []float64{1},
+		},
+		{
+			name:   "last sample in the last bucket",
+			buffer: newTestRingBuffer(16, 3, 1, 2),
+			from:   seconds(0), to: seconds(2), buckets: 2,
+			xs: []float64{-2, -1, 0}, ys: []float64{3, 1, 2},
+		},
+		{
+			name:   "empty window",
+			buffer: newTestRingBuffer(16, 0, 1, 2, 3),
+			from:   seconds(2), to: seconds(2), buckets: 10,
+			xs: []float64{-1, 0}, ys: []float64{1, 2},
+		},
+		{
+			name:   "empty buffer",
+			buffer: NewPlotRingBuffer(4),
+			from:   seconds(0), to: seconds(10), buckets: 10,
+		},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			xs, ys := td.buffer.window(td.from, td.to, td.buckets)
+			assert.Equal(t, td.xs, xs)
+			assert.Equal(t, td.ys, ys)
+		})
+	}
+}
+
+func TestPlotRingBufferClear(t *testing.T) {
+	b := newTestRingBuffer(4, 0, 1, 2, 3, 4, 5)
+	assert.Equal(t, 4, b.Len())
+
+	b.Clear()
+	assert.Equal(t, 0, b.Len())
+	b.Add(time.Unix(9, 0), 9)
+	xs, ys := b.window(seconds(0), seconds(9), 10)
+	assert.Equal(t, []float64{0}, xs)
+	assert.Equal(t, []float64{9}, ys)
+}
+
+func TestPlotRingBufferConcurrentAdd(t *testing.T) {
+	const samples = 10000
+	b := NewPlotRingBuffer(256)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < samples; i++ {
+			b.Add(time.Unix(0, int64(i)), float64(i))
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		xs, ys := b.window(0, samples, 64)
+		if !assert.Equal(t, len(xs), len(ys)) {
+			break
+		}
+		for j := 1; j < len(xs); j++ {
+			if !assert.LessOrEqual(t, xs[j-1], xs[j], "samples expected in chronological order") {
+				break
+			}
+		}
+	}
+	wg.Wait()
+	assert.Equal(t, 256, b.Len())
+}
@@ -2,14 +2,23 @@ package giu
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"runtime"
+	"sync"
 
 	"github.com/AllenDang/giu/imgui"
 )
 
 type Texture struct {
-	id imgui.TextureID
+	id     imgui.TextureID
+	width  int
+	height int
+
+	mu           sync.Mutex
+	staging      *image.RGBA
+	dirty        image.Rectangle
+	updateQueued bool
 }
 
 type loadImageResult struct {
@@ -19,28 +28,187 @@ type loadImageResult struct {
 
 // Create new texture from rgba.
 // Note: this function has to be invokded in a go routine.
-// If call this in mainthread will result in stuck.
+// If call this in mainthread will result in stuck, use EnqueueNewTextureFromRgba instead.
 func NewTextureFromRgba(rgba *image.RGBA) (*Texture, error) {
+	return NewTextureFromRgbaV(rgba, imgui.TextureOptions{})
+}
+
+// Create new texture from rgba, which is sampled as described by options.
+// Note: this function has to be invokded in a go routine, see NewTextureFromRgba.
+func NewTextureFromRgbaV(rgba *image.RGBA, options imgui.TextureOptions) (*Texture, error) {
 	Update()
 	result := CallVal(func() interface{} {
-		texId, err := Context.renderer.LoadImage(rgba)
+		texId, err := Context.renderer.LoadImage(texturePixels(rgba), options)
 		return &loadImageResult{id: texId, err: err}
 	})
 
 	if tid, ok := result.(*loadImageResult); ok {
-		texture := Texture{id: tid.id}
-		if tid.err == nil {
-			// Set finalizer
-			runtime.SetFinalizer(&texture, (*Texture).release)
-		}
-		return &texture, tid.err
+		return newTexture(tid.id, rgba), tid.err
 	}
 	return nil, errors.New("Unknown error occurred")
 }
 
+// Create new texture from any image.
+// Note: this function has to be invokded in a go routine, see NewTextureFromRgba.
+func NewTextureFromImage(img image.Image) (*Texture, error) {
+	return NewTextureFromRgba(ImageToRgba(img))
+}
+
+// EnqueueNewTextureFromRgba creates the texture before the next frame and passes it to loadCb,
+// which is invoked in mainthread. Unlike NewTextureFromRgba it never blocks, so it can be called
+// from the ui loop as well as from go routines.
+func EnqueueNewTextureFromRgba(rgba *image.RGBA, loadCb func(texture *Texture, err error)) {
+	EnqueueNewTextureFromRgbaV(rgba, imgui.TextureOptions{}, loadCb)
+}
+
+// EnqueueNewTextureFromRgbaV works like EnqueueNewTextureFromRgba, the texture is sampled as described by options.
+func EnqueueNewTextureFromRgbaV(rgba *image.RGBA, options imgui.TextureOptions, loadCb func(texture *Texture, err error)) {
+	pixels := texturePixels(rgba)
+	callBetweenFrames(func() {
+		texId, err := Context.renderer.LoadImage(pixels, options)
+		if loadCb != nil {
+			loadCb(newTexture(texId, rgba), err)
+		}
+	})
+}
+
+func newTexture(id imgui.TextureID, rgba *image.RGBA) *Texture {
+	texture := &Texture{
+		id:     id,
+		width:  rgba.Bounds().Dx(),
+		height: rgba.Bounds().Dy(),
+	}
+
+	if id != 0 {
+		runtime.SetFinalizer(texture, (*Texture).release)
+	}
+
+	return texture
+}
+
+// ID returns the id the texture is drawn with, e.g. to match the textures passed to SVGExportOptions.Images.
+// It's 0 once the texture is released.
+func (t *Texture) ID() imgui.TextureID {
+	return t.id
+}
+
+// Size returns the size of the texture in pixels.
+func (t *Texture) Size() (width, height int) {
+	return t.width, t.height
+}
+
+// Update replaces the pixels of the texture. The bounds of rgba locate the pixels in the texture,
+// so a sub image of a frame updates only that part of it.
+// Pixels are copied and uploaded before the next frame, so rgba can be reused right after the call.
+// Update can be called from any go routine, and from the ui loop.
+func (t *Texture) Update(rgba *image.RGBA) error {
+	bounds := rgba.Bounds()
+	if !bounds.In(image.Rect(0, 0, t.width, t.height)) {
+		return fmt.Errorf("bounds %v exceed texture size %dx%d", bounds, t.width, t.height)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.staging == nil {
+		t.staging = image.NewRGBA(image.Rect(0, 0, t.width, t.height))
+	}
+
+	rowSize := bounds.Dx() * 4
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		src := rgba.Pix[rgba.PixOffset(bounds.Min.X, y):]
+		dst := t.staging.Pix[t.staging.PixOffset(bounds.Min.X, y):]
+		copy(dst[:rowSize], src[:rowSize])
+		unpremultiplyRow(dst[:rowSize])
+	}
+	t.dirty = t.dirty.Union(bounds)
+
+	if !t.updateQueued {
+		t.updateQueued = true
+		callBetweenFrames(t.upload)
+	}
+
+	return nil
+}
+
+func (t *Texture) upload() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.updateQueued = false
+	if t.id == 0 || t.dirty.Empty() {
+		return
+	}
+
+	_ = Context.renderer.UpdateImage(t.id, t.staging.SubImage(t.dirty).(*image.RGBA))
+	t.dirty = image.Rectangle{}
+}
+
+// Release releases the texture before the next frame instead of waiting for the garbage collector.
+// The texture must not be used afterwards.
+func (t *Texture) Release() {
+	runtime.SetFinalizer(t, nil)
+	callBetweenFrames(func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		if t.id != 0 {
+			Context.renderer.ReleaseImage(t.id)
+			t.id = 0
+			t.staging = nil
+		}
+	})
+}
+
 func (t *Texture) release() {
-	Update()
-	Call(func() {
-		Context.renderer.ReleaseImage(t.id)
+	id := t.id
+	callBetweenFrames(func() {
+		Context.renderer.ReleaseImage(id)
 	})
 }
+
+// texturePixels returns the pixels of rgba as the renderer takes them, with non-premultiplied alpha since
+// it blends textures by their alpha. Images without translucent pixels are passed as they are.
+func texturePixels(rgba *image.RGBA) *image.RGBA {
+	bounds := rgba.Bounds()
+	rowSize := bounds.Dx() * 4
+
+	translucent := false
+	for y := bounds.Min.Y; y < bounds.Max.Y && !translucent; y++ {
+		row := rgba.Pix[rgba.PixOffset(bounds.Min.X, y):][:rowSize]
+		for i := 3; i < len(row); i += 4 {
+			if row[i] != 0 && row[i] != 0xff {
+				translucent = true
+				break
+			}
+		}
+	}
+	if !translucent {
+		return rgba
+	}
+
+	pixels := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		dst := pixels.Pix[pixels.PixOffset(bounds.Min.X, y):][:rowSize]
+		copy(dst, rgba.Pix[rgba.PixOffset(bounds.Min.X, y):][:rowSize])
+		unpremultiplyRow(dst)
+	}
+	return pixels
+}
+
+// unpremultiplyRow converts a row of premultiplied pixels to non-premultiplied alpha in place.
+func unpremultiplyRow(row []uint8) {
+	for i := 0; i+3 < len(row); i += 4 {
+		a := uint32(row[i+3])
+		if a == 0 || a == 0xff {
+			continue
+		}
+		for c := i; c < i+3; c++ {
+			v := (uint32(row[c])*0xff + a/2) / a
+			if v > 0xff {
+				v = 0xff
+			}
+			row[c] = uint8(v)
+		}
+	}
+}
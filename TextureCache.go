@@ -0,0 +1,246 @@
+package giu
+
+import (
+	"container/list"
+	"sync"
+)
+
+// TextureCacheStats describes the state of a TextureCache.
+type TextureCacheStats struct {
+	// Textures is the count of live textures in the cache.
+	Textures int
+	// Bytes is the estimated GPU memory used by those textures.
+	Bytes int
+	// Budget is the memory budget of the cache in bytes.
+	Budget int
+	// Hits and Misses count lookups by Get and Acquire.
+	Hits   int
+	Misses int
+	// Evictions counts textures released to stay within the budget.
+	Evictions int
+}
+
+type textureCacheEntry struct {
+	key     string
+	texture *Texture
+	bytes   int
+	refs    int
+	removed bool // dropped from the cache while acquired, released with the last reference
+}
+
+// TextureRef is a texture acquired from a TextureCache, it isn't evicted until the reference is released.
+type TextureRef struct {
+	cache    *TextureCache
+	entry    *textureCacheEntry
+	released bool
+}
+
+// Texture returns the acquired texture.
+func (r *TextureRef) Texture() *Texture {
+	return r.entry.texture
+}
+
+// valid reports whether the acquired texture is still the one stored in the cache.
+func (r *TextureRef) valid() bool {
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+	return !r.entry.removed
+}
+
+// Release drops the reference. If the texture was replaced or removed from the cache meanwhile,
+// it is released along with its last reference. Calling Release again has no effect.
+func (r *TextureRef) Release() {
+	r.cache.release(r)
+}
+
+// TextureCache keeps textures by key (e.g. file path, URL or hash) within a memory budget.
+// When the budget is exceeded, least recently used textures that are not acquired are evicted.
+// Evicted textures are released on the main thread between frames.
+// All methods can be called from any go routine.
+type TextureCache struct {
+	mu      sync.Mutex
+	budget  int
+	entries map[string]*list.Element
+	lru     *list.List
+	stats   TextureCacheStats
+}
+
+// NewTextureCache creates a cache which keeps at most budget bytes of textures,
+// a texture takes width*height*4 bytes.
+func NewTextureCache(budget int) *TextureCache {
+	return &TextureCache{
+		budget:  budget,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// SetBudget changes the memory budget, evicting textures if needed.
+func (c *TextureCache) SetBudget(budget int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.budget = budget
+	c.evict()
+}
+
+// Put adds texture with key to the cache and returns it. A texture previously stored with key
+// is released, or once its last reference is released if it is acquired.
+// The cache owns the texture afterwards, don't release it directly.
+func (c *TextureCache) Put(key string, texture *Texture) *Texture {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.put(key, texture)
+	c.evict()
+	return texture
+}
+
+// putAcquired works like Put, but acquires the texture before evicting, so it's kept even if
+// acquired textures already fill the budget or it's larger than the budget on its own.
+func (c *TextureCache) putAcquired(key string, texture *Texture) *TextureRef {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.put(key, texture)
+	entry.refs++
+	c.evict()
+	return &TextureRef{cache: c, entry: entry}
+}
+
+func (c *TextureCache) put(key string, texture *Texture) *textureCacheEntry {
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*textureCacheEntry)
+		if entry.texture == texture {
+			// Removing it would release the texture stored again.
+			c.lru.MoveToFront(elem)
+			return entry
+		}
+		c.remove(elem)
+	}
+
+	width, height := texture.Size()
+	entry := &textureCacheEntry{
+		key:     key,
+		texture: texture,
+		bytes:   width * height * 4,
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.stats.Bytes += entry.bytes
+	return entry
+}
+
+// Get returns the texture stored with key and marks it as recently used.
+// The texture may be evicted later on, use Acquire to keep it.
+func (c *TextureCache) Get(key string) (*Texture, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.lookup(key)
+	if entry == nil {
+		return nil, false
+	}
+	return entry.texture, true
+}
+
+// Acquire works like Get, but the texture is never evicted until the returned reference is released.
+func (c *TextureCache) Acquire(key string) (*TextureRef, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.lookup(key)
+	if entry == nil {
+		return nil, false
+	}
+	entry.refs++
+	return &TextureRef{cache: c, entry: entry}, true
+}
+
+func (c *TextureCache) release(r *TextureRef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if r.released {
+		return
+	}
+	r.released = true
+
+	entry := r.entry
+	entry.refs--
+	if entry.removed {
+		if entry.refs == 0 {
+			entry.texture.Release()
+		}
+		return
+	}
+	c.evict()
+}
+
+// Remove removes the texture stored with key, and releases it unless it is acquired.
+func (c *TextureCache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Clear removes all textures, releasing the ones that are not acquired.
+func (c *TextureCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Stats returns the current statistics of the cache.
+func (c *TextureCache) Stats() TextureCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Textures = c.lru.Len()
+	stats.Budget = c.budget
+	return stats
+}
+
+func (c *TextureCache) lookup(key string) *textureCacheEntry {
+	elem, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil
+	}
+
+	c.stats.Hits++
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*textureCacheEntry)
+}
+
+func (c *TextureCache) remove(elem *list.Element) {
+	entry := elem.Value.(*textureCacheEntry)
+	c.lru.Remove(elem)
+	delete(c.entries, entry.key)
+	c.stats.Bytes -= entry.bytes
+
+	// An acquired texture is still in use, it's released with its last reference.
+	entry.removed = true
+	if entry.refs == 0 {
+		entry.texture.Release()
+	}
+}
+
+// evict removes least recently used textures until the cache fits in its budget.
+func (c *TextureCache) evict() {
+	elem := c.lru.Back()
+	for c.stats.Bytes > c.budget && elem != nil {
+		prev := elem.Prev()
+		if elem.Value.(*textureCacheEntry).refs == 0 {
+			c.remove(elem)
+			c.stats.Evictions++
+		}
+		elem = prev
+	}
+}
@@ -0,0 +1,116 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTexture(width, height int) *Texture {
+	return &Texture{width: width, height: height}
+}
+
+func TestTextureCacheEviction(t *testing.T) {
+	cache := NewTextureCache(3 * 16)
+	cache.Put("a", newTestTexture(2, 2))
+	cache.Put("b", newTestTexture(2, 2))
+	cache.Put("c", newTestTexture(2, 2))
+
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+	cache.Put("d", newTestTexture(2, 2))
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "least recently used texture expected to be evicted")
+	for _, key := range []string{"a", "c", "d"} {
+		_, ok = cache.Get(key)
+		assert.True(t, ok, key)
+	}
+
+	stats := cache.Stats()
+	assert.Equal(t, 3, stats.Textures)
+	assert.Equal(t, 3*16, stats.Bytes)
+	assert.Equal(t, 1, stats.Evictions)
+}
+
+func TestTextureCacheAcquire(t *testing.T) {
+	cache := NewTextureCache(16)
+	texture := newTestTexture(2, 2)
+	cache.Put("a", texture)
+
+	ref, ok := cache.Acquire("a")
+	assert.True(t, ok)
+	assert.Equal(t, texture, ref.Texture())
+
+	cache.SetBudget(0)
+	assert.Equal(t, 1, cache.Stats().Textures, "acquired texture expected to be kept")
+
+	ref.Release()
+	assert.Equal(t, 0, cache.Stats().Textures, "released texture expected to be evicted")
+
+	ref.Release()
+	assert.Equal(t, 1, cache.Stats().Evictions)
+}
+
+func TestTextureCacheReplaceAcquired(t *testing.T) {
+	cache := NewTextureCache(16)
+	old := newTestTexture(2, 2)
+	cache.Put("a", old)
+	oldRef, _ := cache.Acquire("a")
+
+	replacement := newTestTexture(2, 2)
+	cache.Put("a", replacement)
+	newRef, ok := cache.Acquire("a")
+	assert.True(t, ok)
+	assert.Equal(t, replacement, newRef.Texture())
+	assert.Equal(t, old, oldRef.Texture(), "replaced texture expected to stay with its holder")
+
+	// Releasing the replaced texture must not drop the reference to the new one.
+	oldRef.Release()
+	cache.SetBudget(0)
+	assert.Equal(t, 1, cache.Stats().Textures)
+
+	newRef.Release()
+	assert.Equal(t, 0, cache.Stats().Textures)
+}
+
+func pendingFrameTasks() int {
+	frameTasksMutex.Lock()
+	defer frameTasksMutex.Unlock()
+	return len(frameTasks)
+}
+
+func TestTextureCachePutSameTexture(t *testing.T) {
+	cache := NewTextureCache(16)
+	texture := newTestTexture(2, 2)
+	cache.Put("a", texture)
+
+	tasks := pendingFrameTasks()
+	cache.Put("a", texture)
+	assert.Equal(t, tasks, pendingFrameTasks(), "texture stored again expected not to be released")
+
+	got, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, texture, got)
+	assert.Equal(t, 16, cache.Stats().Bytes)
+}
+
+func TestTextureCachePutAcquired(t *testing.T) {
+	cache := NewTextureCache(16)
+	cache.Put("a", newTestTexture(2, 2))
+	pinned, _ := cache.Acquire("a")
+
+	// Acquired textures fill the budget, the new one would be evicted by Put right away.
+	cache.Put("b", newTestTexture(2, 2))
+	_, ok := cache.Get("b")
+	assert.False(t, ok)
+
+	ref := cache.putAcquired("c", newTestTexture(4, 4))
+	_, ok = cache.Get("c")
+	assert.True(t, ok, "texture larger than the budget expected to be kept while acquired")
+
+	ref.Release()
+	_, ok = cache.Get("c")
+	assert.False(t, ok)
+	pinned.Release()
+}
@@ -0,0 +1,27 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTexturePixels(t *testing.T) {
+	rgba := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	rgba.SetRGBA(0, 0, color.RGBA{255, 0, 0, 255})
+	assert.True(t, rgba == texturePixels(rgba), "opaque and transparent pixels expected to be passed as they are")
+
+	rgba.Set(1, 0, color.NRGBA{255, 128, 0, 128})
+	premultiplied := rgba.RGBAAt(1, 0)
+	pixels := texturePixels(rgba)
+	assert.Equal(t, []uint8{255, 0, 0, 255, 255, 128, 0, 128}, pixels.Pix)
+	assert.Equal(t, premultiplied, rgba.RGBAAt(1, 0), "source pixels expected to be left untouched")
+
+	// Only the pixels within the bounds of a sub image are converted.
+	sub := rgba.SubImage(image.Rect(1, 0, 2, 1)).(*image.RGBA)
+	pixels = texturePixels(sub)
+	assert.Equal(t, sub.Bounds(), pixels.Bounds())
+	assert.Equal(t, color.RGBA{255, 128, 0, 128}, pixels.RGBAAt(1, 0))
+}
@@ -0,0 +1,427 @@
+package giu
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+// TreeViewNode is a node of a TreeView, ID identifies it in the whole tree.
+type TreeViewNode struct {
+	ID    string
+	Label string
+	// Icon is shown before the label, e.g. a glyph of an icon font.
+	Icon string
+	// Leaf nodes have no children and can't be expanded.
+	Leaf bool
+}
+
+// TreeNodeProvider supplies the nodes of a TreeView. Children are only asked for when their parent is expanded.
+type TreeNodeProvider interface {
+	// Children returns the children of the node with id, or the roots of the tree for an empty id.
+	Children(id string) ([]TreeViewNode, error)
+}
+
+type treeChildren struct {
+	nodes   []TreeViewNode
+	err     error
+	loading bool
+}
+
+// TreeViewState keeps the loaded children, the expanded nodes and the selection of a TreeView between frames.
+type TreeViewState struct {
+	mu       sync.Mutex
+	children map[string]*treeChildren
+	parents  map[string]string
+
+	expanded map[string]bool
+	toggle   map[string]bool // expand states applied to nodes in the next frame
+
+	selected map[string]bool
+	anchor   string // last clicked node, where shift ranges start
+	cursor   string // node moved by the keyboard
+	reveal   string // node to scroll to once it's shown
+
+	visible []string // nodes in display order of the last frame
+}
+
+func (s *TreeViewState) init() {
+	if s.expanded == nil {
+		s.mu.Lock()
+		if s.children == nil {
+			s.children = make(map[string]*treeChildren)
+		}
+		s.parents = make(map[string]string)
+		s.mu.Unlock()
+		s.expanded = make(map[string]bool)
+		s.toggle = make(map[string]bool)
+		s.selected = make(map[string]bool)
+	}
+}
+
+// IsExpanded reports whether the node with id is expanded.
+func (s *TreeViewState) IsExpanded(id string) bool {
+	return s.expanded[id]
+}
+
+// Expand expands the node with id in the next frame, loading its children.
+func (s *TreeViewState) Expand(id string) {
+	s.init()
+	s.toggle[id] = true
+}
+
+// Collapse collapses the node with id in the next frame.
+func (s *TreeViewState) Collapse(id string) {
+	s.init()
+	s.toggle[id] = false
+}
+
+// Reveal expands the nodes of path, going from a root down to a node, and scrolls to the last one once it's shown.
+func (s *TreeViewState) Reveal(path ...string) {
+	if len(path) == 0 {
+		return
+	}
+	for _, id := range path[:len(path)-1] {
+		s.Expand(id)
+	}
+	s.reveal = path[len(path)-1]
+}
+
+// Reload drops the loaded children of the node with id, they are asked for again when it's shown expanded.
+// An empty id reloads the whole tree.
+func (s *TreeViewState) Reload(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id == "" {
+		s.children = make(map[string]*treeChildren)
+		return
+	}
+	delete(s.children, id)
+}
+
+// IsSelected reports whether the node with id is selected.
+func (s *TreeViewState) IsSelected(id string) bool {
+	return s.selected[id]
+}
+
+// Selection returns the ids of the selected nodes, sorted.
+func (s *TreeViewState) Selection() []string {
+	ids := make([]string, 0, len(s.selected))
+	for id := range s.selected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// SetSelection selects the nodes with ids, replacing the selection.
+func (s *TreeViewState) SetSelection(ids ...string) {
+	s.init()
+	s.selected = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		s.selected[id] = true
+	}
+	if len(ids) > 0 {
+		s.anchor, s.cursor = ids[len(ids)-1], ids[len(ids)-1]
+	}
+}
+
+// ClearSelection deselects all nodes.
+func (s *TreeViewState) ClearSelection() {
+	s.SetSelection()
+}
+
+// parent returns the id of the parent of the node with id, which is empty for roots.
+func (s *TreeViewState) parent(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.parents[id]
+}
+
+// load returns the children of the node with id, loading them if needed. Async loads are marked loading until they are done.
+func (s *TreeViewState) load(provider TreeNodeProvider, id string, async bool) *treeChildren {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.children[id]; ok {
+		return c
+	}
+
+	c := &treeChildren{}
+	s.children[id] = c
+	store := func(nodes []TreeViewNode, err error) {
+		c.nodes, c.err, c.loading = nodes, err, false
+		for _, n := range nodes {
+			s.parents[n.ID] = id
+		}
+	}
+
+	// The provider is called without holding the lock, it may call Reload or take a while.
+	if !async {
+		s.mu.Unlock()
+		nodes, err := provider.Children(id)
+		s.mu.Lock()
+		store(nodes, err)
+		return c
+	}
+
+	c.loading = true
+	go func() {
+		nodes, err := provider.Children(id)
+		s.mu.Lock()
+		// Skip results of children dropped by Reload meanwhile.
+		if s.children[id] == c {
+			store(nodes, err)
+		}
+		s.mu.Unlock()
+		Update()
+	}()
+	return c
+}
+
+// click updates the selection for a click on the node with id.
+func (s *TreeViewState) click(id string, multiSelect bool) {
+	io := Context.IO()
+	s.cursor = id
+	switch {
+	case multiSelect && io.KeyShiftPressed():
+		if !io.KeyCtrlPressed() {
+			s.selected = make(map[string]bool)
+		}
+		from, to := -1, -1
+		for i, v := range s.visible {
+			if v == s.anchor {
+				from = i
+			}
+			if v == id {
+				to = i
+			}
+		}
+		if from < 0 {
+			from = to
+		}
+		if from > to {
+			from, to = to, from
+		}
+		for i := from; i <= to && i >= 0; i++ {
+			s.selected[s.visible[i]] = true
+		}
+	case multiSelect && io.KeyCtrlPressed():
+		if s.selected[id] {
+			delete(s.selected, id)
+		} else {
+			s.selected[id] = true
+		}
+		s.anchor = id
+	default:
+		s.SetSelection(id)
+	}
+}
+
+type TreeViewWidget struct {
+	id                string
+	state             *TreeViewState
+	provider          TreeNodeProvider
+	width             float32
+	height            float32
+	async             bool
+	multiSelect       bool
+	onSelectionChange func(ids []string)
+	contextMenu       func(node TreeViewNode) Layout
+}
+
+// TreeView shows the nodes supplied by provider as a tree in a scrolling area, width or height -1 fill the available space.
+// Children are loaded when their parent is expanded by clicking its arrow or double-clicking it.
+// Clicks select nodes, the arrow keys move the selection and left and right collapse and expand nodes.
+func TreeView(id string, state *TreeViewState, provider TreeNodeProvider, width, height float32) *TreeViewWidget {
+	return TreeViewV(id, state, provider, width, height, false, false, nil, nil)
+}
+
+// TreeViewV works like TreeView. If async is set, children are loaded in a go routine while a placeholder is shown.
+// multiSelect allows selecting several nodes with ctrl and shift clicks, onSelectionChange is invoked with the
+// selected ids when clicks or keys changed them. contextMenu returns the layout of the popup menu of a right-clicked node.
+func TreeViewV(id string, state *TreeViewState, provider TreeNodeProvider, width, height float32, async, multiSelect bool, onSelectionChange func(ids []string), contextMenu func(node TreeViewNode) Layout) *TreeViewWidget {
+	return &TreeViewWidget{
+		id:                id,
+		state:             state,
+		provider:          provider,
+		width:             width,
+		height:            height,
+		async:             async,
+		multiSelect:       multiSelect,
+		onSelectionChange: onSelectionChange,
+		contextMenu:       contextMenu,
+	}
+}
+
+func (t *TreeViewWidget) Build() {
+	s := t.state
+	if s == nil || t.provider == nil {
+		return
+	}
+	s.init()
+
+	imgui.BeginChildV(t.id, resolveSize(t.width, t.height), false, 0)
+	s.visible = s.visible[:0]
+	changed := t.buildChildren("")
+	if imgui.IsWindowFocused() && t.handleKeys() {
+		changed = true
+	}
+	imgui.EndChild()
+
+	if changed && t.onSelectionChange != nil {
+		t.onSelectionChange(s.Selection())
+	}
+}
+
+// buildChildren builds the children of the node with id, and reports whether the selection changed.
+func (t *TreeViewWidget) buildChildren(id string) (changed bool) {
+	s := t.state
+	c := s.load(t.provider, id, t.async)
+
+	s.mu.Lock()
+	nodes, err, loading := c.nodes, c.err, c.loading
+	s.mu.Unlock()
+
+	if loading || err != nil {
+		text := "Loading..."
+		if err != nil {
+			text = err.Error()
+		}
+		disabled := Vec4ToRGBA(imgui.CurrentStyle().GetColor(imgui.StyleColorTextDisabled))
+		LabelV(text, &disabled, nil).Build()
+		return false
+	}
+
+	for _, node := range nodes {
+		if t.buildNode(node) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (t *TreeViewWidget) buildNode(node TreeViewNode) (changed bool) {
+	s := t.state
+	s.visible = append(s.visible, node.ID)
+
+	flags := imgui.TreeNodeFlagsOpenOnArrow | imgui.TreeNodeFlagsOpenOnDoubleClick | imgui.TreeNodeFlagsSpanAvailWidth
+	if node.Leaf {
+		flags |= imgui.TreeNodeFlagsLeaf | imgui.TreeNodeFlagsNoTreePushOnOpen
+	}
+	if s.selected[node.ID] {
+		flags |= imgui.TreeNodeFlagsSelected
+	}
+	if open, ok := s.toggle[node.ID]; ok && !node.Leaf {
+		imgui.SetNextItemOpen(open, imgui.ConditionAlways)
+		delete(s.toggle, node.ID)
+	}
+
+	label := node.Label
+	if node.Icon != "" {
+		label = node.Icon + " " + label
+	}
+	wasOpen := s.expanded[node.ID]
+	open := imgui.TreeNodeV(label+"##"+node.ID, flags)
+	if !node.Leaf {
+		s.expanded[node.ID] = open
+	}
+
+	// Clicks on the arrow only toggle the node.
+	if imgui.IsItemClicked() && open == wasOpen {
+		s.click(node.ID, t.multiSelect)
+		changed = true
+	}
+	if imgui.IsItemClickedV(1) && !s.selected[node.ID] {
+		s.SetSelection(node.ID)
+		changed = true
+	}
+	if s.reveal == node.ID {
+		// Only scroll if the node is out of view, to the middle.
+		top, bottom := imgui.WindowPos().Y, imgui.WindowPos().Y+imgui.WindowHeight()
+		if imgui.ItemRectMin().Y < top || imgui.ItemRectMax().Y > bottom {
+			imgui.SetScrollHereY(0.5)
+		}
+		s.reveal = ""
+	}
+	if t.contextMenu != nil && imgui.BeginPopupContextItemV("context "+node.ID, 1) {
+		t.contextMenu(node).Build()
+		imgui.EndPopup()
+	}
+
+	if open && !node.Leaf {
+		if t.buildChildren(node.ID) {
+			changed = true
+		}
+		imgui.TreePop()
+	}
+	return changed
+}
+
+// handleKeys moves the selection with the arrow keys, and reports whether it changed.
+func (t *TreeViewWidget) handleKeys() bool {
+	s := t.state
+	pos := -1
+	for i, id := range s.visible {
+		if id == s.cursor {
+			pos = i
+			break
+		}
+	}
+	if len(s.visible) == 0 {
+		return false
+	}
+
+	next := pos
+	switch {
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyDownArrow)):
+		next = pos + 1
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyUpArrow)):
+		next = pos - 1
+	case pos < 0:
+		return false
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyRightArrow)):
+		if !s.expanded[s.cursor] {
+			s.Expand(s.cursor)
+			return false
+		}
+		// Move into an expanded node, unless it has no children.
+		if pos+1 < len(s.visible) && s.parent(s.visible[pos+1]) == s.cursor {
+			next = pos + 1
+		}
+	case IsKeyPressed(imgui.KeyIndex(imgui.KeyLeftArrow)):
+		if s.expanded[s.cursor] {
+			s.Collapse(s.cursor)
+			return false
+		}
+		parent := s.parent(s.cursor)
+		if parent == "" {
+			return false
+		}
+		for i, id := range s.visible {
+			if id == parent {
+				next = i
+			}
+		}
+	default:
+		return false
+	}
+
+	if next < 0 {
+		next = 0
+	}
+	if next >= len(s.visible) {
+		next = len(s.visible) - 1
+	}
+	if next == pos {
+		return false
+	}
+	id := s.visible[next]
+	if t.multiSelect && Context.IO().KeyShiftPressed() {
+		s.click(id, true)
+	} else {
+		s.SetSelection(id)
+	}
+	s.reveal = id
+	return true
+}
@@ -1,15 +1,23 @@
 package giu
 
 import (
+	"bytes"
 	"image"
 	"image/color"
 	"image/draw"
-	"image/png"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
 	"os"
 
 	"github.com/AllenDang/giu/imgui"
 )
 
+// LoadImage loads an image file.
+// PNG, JPEG and GIF are supported, more formats (e.g. BMP and WebP from golang.org/x/image)
+// are supported by importing their decoder packages.
 func LoadImage(imgPath string) (*image.RGBA, error) {
 	imgFile, err := os.Open(imgPath)
 	if err != nil {
@@ -17,21 +25,79 @@ func LoadImage(imgPath string) (*image.RGBA, error) {
 	}
 	defer imgFile.Close()
 
-	img, err := png.Decode(imgFile)
+	return LoadImageFromReader(imgFile)
+}
+
+// LoadImageFromReader decodes an image with any registered decoder.
+func LoadImageFromReader(r io.Reader) (*image.RGBA, error) {
+	img, _, err := image.Decode(r)
 	if err != nil {
 		return nil, err
 	}
 
+	return ImageToRgba(img), nil
+}
+
+// LoadImageFromBytes decodes an image with any registered decoder.
+func LoadImageFromBytes(data []byte) (*image.RGBA, error) {
+	return LoadImageFromReader(bytes.NewReader(data))
+}
+
+// ImageToRgba converts img to an *image.RGBA, whose pixels hold premultiplied alpha like any image.RGBA.
+// An *image.RGBA is returned as it is, other images are converted into a new one.
+func ImageToRgba(img image.Image) *image.RGBA {
 	switch trueImg := img.(type) {
 	case *image.RGBA:
-		return trueImg, nil
+		return trueImg
+	case *image.Gray:
+		return grayToRgba(trueImg)
+	case *image.YCbCr:
+		return ycbcrToRgba(trueImg)
 	default:
 		rgba := image.NewRGBA(trueImg.Bounds())
-		draw.Draw(rgba, trueImg.Bounds(), trueImg, image.Pt(0, 0), draw.Src)
-		return rgba, nil
+		draw.Draw(rgba, trueImg.Bounds(), trueImg, trueImg.Bounds().Min, draw.Src)
+		return rgba
 	}
 }
 
+func grayToRgba(img *image.Gray) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+
+	for y := 0; y < bounds.Dy(); y++ {
+		src := img.Pix[y*img.Stride : y*img.Stride+bounds.Dx()]
+		dst := rgba.Pix[y*rgba.Stride : y*rgba.Stride+bounds.Dx()*4]
+		for x, v := range src {
+			dst[x*4] = v
+			dst[x*4+1] = v
+			dst[x*4+2] = v
+			dst[x*4+3] = 0xff
+		}
+	}
+	return rgba
+}
+
+func ycbcrToRgba(img *image.YCbCr) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		dst := rgba.Pix[(y-bounds.Min.Y)*rgba.Stride:]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			yi := img.YOffset(x, y)
+			ci := img.COffset(x, y)
+			r, g, b := color.YCbCrToRGB(img.Y[yi], img.Cb[ci], img.Cr[ci])
+
+			i := (x - bounds.Min.X) * 4
+			dst[i] = r
+			dst[i+1] = g
+			dst[i+2] = b
+			dst[i+3] = 0xff
+		}
+	}
+	return rgba
+}
+
 func ToVec4Color(col color.RGBA) imgui.Vec4 {
 	return imgui.Vec4{
 		X: float32(col.R) / 255,
@@ -41,6 +107,28 @@ func ToVec4Color(col color.RGBA) imgui.Vec4 {
 	}
 }
 
+// Vec4ToRGBA converts an imgui color, e.g. one of the style, to color.RGBA.
+func Vec4ToRGBA(col imgui.Vec4) color.RGBA {
+	return color.RGBA{
+		R: uint8(col.X * 255),
+		G: uint8(col.Y * 255),
+		B: uint8(col.Z * 255),
+		A: uint8(col.W * 255),
+	}
+}
+
+// colorToFloats converts col to the channels edited by imgui color widgets.
+func colorToFloats(col color.RGBA) [4]float32 {
+	return [4]float32{float32(col.R) / 255, float32(col.G) / 255, float32(col.B) / 255, float32(col.A) / 255}
+}
+
+func floatsToColor(col [4]float32) color.RGBA {
+	channel := func(v float32) uint8 {
+		return uint8(math.Round(math.Max(0, math.Min(1, float64(v))) * 255))
+	}
+	return color.RGBA{R: channel(col[0]), G: channel(col[1]), B: channel(col[2]), A: channel(col[3])}
+}
+
 func ToVec2(pt image.Point) imgui.Vec2 {
 	return imgui.Vec2{
 		X: float32(pt.X),
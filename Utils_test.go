@@ -0,0 +1,55 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageToRgbaAlpha(t *testing.T) {
+	// A translucent orange, premultiplied in the result like in any image.RGBA.
+	orange := color.NRGBA{R: 255, G: 128, B: 0, A: 128}
+	want := color.RGBAModel.Convert(orange).(color.RGBA)
+	bounds := image.Rect(0, 0, 2, 2)
+
+	nrgba := image.NewNRGBA(bounds)
+	rgba := image.NewRGBA(bounds)
+	paletted := image.NewPaletted(bounds, color.Palette{orange})
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			nrgba.Set(x, y, orange)
+			rgba.Set(x, y, orange)
+		}
+	}
+
+	tt := []struct {
+		name string
+		img  image.Image
+	}{
+		{name: "NRGBA", img: nrgba},
+		{name: "RGBA", img: rgba},
+		{name: "Paletted", img: paletted},
+		{name: "SubImage", img: nrgba.SubImage(image.Rect(1, 1, 2, 2))},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			result := ImageToRgba(td.img)
+			assert.Equal(t, td.img.Bounds(), result.Bounds())
+			b := result.Bounds()
+			assert.Equal(t, want, result.RGBAAt(b.Min.X, b.Min.Y))
+		})
+	}
+}
+
+func TestImageToRgbaCopies(t *testing.T) {
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	converted := ImageToRgba(nrgba)
+	converted.Pix[0] = 0xff
+	assert.Equal(t, uint8(0), nrgba.Pix[0], "source pixels expected to be left untouched")
+
+	rgba := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	assert.True(t, rgba == ImageToRgba(rgba), "*image.RGBA expected to be returned as it is")
+}
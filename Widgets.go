@@ -2,6 +2,7 @@ package giu
 
 import (
 	"image/color"
+	"time"
 
 	"github.com/AllenDang/giu/imgui"
 )
@@ -231,6 +232,147 @@ func Combo(label, previewValue string, items []string, selected *int32, flags in
 	}
 }
 
+type ListBoxWidget struct {
+	label       string
+	items       []string
+	selected    *int32
+	heightItems int
+	changed     func()
+}
+
+func (l *ListBoxWidget) Build() {
+	if imgui.ListBoxV(l.label, l.selected, l.items, l.heightItems) && l.changed != nil {
+		l.changed()
+	}
+}
+
+func ListBox(label string, items []string, selected *int32) *ListBoxWidget {
+	return ListBoxV(label, items, selected, -1, nil)
+}
+
+// ListBoxV creates a framed list of items showing heightItems of them, -1 picks a height for up to 7 items.
+func ListBoxV(label string, items []string, selected *int32, heightItems int, changed func()) *ListBoxWidget {
+	return &ListBoxWidget{
+		label:       label,
+		items:       items,
+		selected:    selected,
+		heightItems: heightItems,
+		changed:     changed,
+	}
+}
+
+type ColorEditWidget struct {
+	label   string
+	color   *color.RGBA
+	noAlpha bool
+	flags   int
+	changed func()
+}
+
+func (c *ColorEditWidget) Build() {
+	col := colorToFloats(*c.color)
+	var changed bool
+	if c.noAlpha {
+		rgb := [3]float32{col[0], col[1], col[2]}
+		changed = imgui.ColorEdit3V(c.label, &rgb, c.flags)
+		copy(col[:3], rgb[:])
+	} else {
+		changed = imgui.ColorEdit4V(c.label, &col, c.flags)
+	}
+	if changed {
+		*c.color = floatsToColor(col)
+		if c.changed != nil {
+			c.changed()
+		}
+	}
+}
+
+func ColorEdit(label string, col *color.RGBA) *ColorEditWidget {
+	return ColorEditV(label, col, 0, nil)
+}
+
+// ColorEditV creates input fields for the channels of col with a button opening a color picker.
+// flags are the imgui.ColorEditFlags to apply, e.g. imgui.ColorEditFlagsNoAlpha to hide the alpha channel.
+func ColorEditV(label string, col *color.RGBA, flags int, changed func()) *ColorEditWidget {
+	return &ColorEditWidget{
+		label:   label,
+		color:   col,
+		flags:   flags,
+		changed: changed,
+	}
+}
+
+func ColorEdit3(label string, col *color.RGBA) *ColorEditWidget {
+	return ColorEdit3V(label, col, 0, nil)
+}
+
+// ColorEdit3V works like ColorEditV for the red, green and blue channels only, the alpha of col is left untouched.
+func ColorEdit3V(label string, col *color.RGBA, flags int, changed func()) *ColorEditWidget {
+	return &ColorEditWidget{
+		label:   label,
+		color:   col,
+		noAlpha: true,
+		flags:   flags,
+		changed: changed,
+	}
+}
+
+type ColorPickerWidget struct {
+	label   string
+	color   *color.RGBA
+	noAlpha bool
+	flags   int
+	changed func()
+}
+
+func (c *ColorPickerWidget) Build() {
+	col := colorToFloats(*c.color)
+	var changed bool
+	if c.noAlpha {
+		rgb := [3]float32{col[0], col[1], col[2]}
+		changed = imgui.ColorPicker3V(c.label, &rgb, c.flags)
+		copy(col[:3], rgb[:])
+	} else {
+		changed = imgui.ColorPicker4V(c.label, &col, c.flags)
+	}
+	if changed {
+		*c.color = floatsToColor(col)
+		if c.changed != nil {
+			c.changed()
+		}
+	}
+}
+
+func ColorPicker(label string, col *color.RGBA) *ColorPickerWidget {
+	return ColorPickerV(label, col, 0, nil)
+}
+
+// ColorPickerV creates a color picker for col, flags are the imgui.ColorEditFlags to apply,
+// e.g. imgui.ColorEditFlagsNoAlpha to hide the alpha channel.
+func ColorPickerV(label string, col *color.RGBA, flags int, changed func()) *ColorPickerWidget {
+	return &ColorPickerWidget{
+		label:   label,
+		color:   col,
+		flags:   flags,
+		changed: changed,
+	}
+}
+
+func ColorPicker3(label string, col *color.RGBA) *ColorPickerWidget {
+	return ColorPicker3V(label, col, 0, nil)
+}
+
+// ColorPicker3V works like ColorPickerV for the red, green and blue channels only, the alpha of col is left untouched.
+func ColorPicker3V(label string, col *color.RGBA, flags int, changed func()) *ColorPickerWidget {
+	return &ColorPickerWidget{
+		label:   label,
+		color:   col,
+		noAlpha: true,
+		flags:   flags,
+		changed: changed,
+	}
+}
+
 type ContextMenuWidget struct {
 	label       string
 	mouseButton int
@@ -286,6 +428,39 @@ func DragIntV(label string, value *int32, speed float32, min, max int32, format
 	}
 }
 
+type DragFloatWidget struct {
+	label   string
+	value   *float32
+	speed   float32
+	min     float32
+	max     float32
+	format  string
+	changed func()
+}
+
+func (d *DragFloatWidget) Build() {
+	if imgui.DragFloatV(d.label, d.value, d.speed, d.min, d.max, d.format, 1.0) && d.changed != nil {
+		d.changed()
+	}
+}
+
+func DragFloat(label string, value *float32) *DragFloatWidget {
+	return DragFloatV(label, value, 1.0, 0, 0, "%.3f", nil)
+}
+
+// DragFloatV creates a draggable float, min equal to max disables clamping.
+func DragFloatV(label string, value *float32, speed float32, min, max float32, format string, changed func()) *DragFloatWidget {
+	return &DragFloatWidget{
+		label:   label,
+		value:   value,
+		speed:   speed,
+		min:     min,
+		max:     max,
+		format:  format,
+		changed: changed,
+	}
+}
+
 type GroupWidget struct {
 	layout Layout
 }
@@ -305,9 +480,13 @@ func Group(layout Layout) *GroupWidget {
 }
 
 type ImageWidget struct {
-	texture *Texture
-	width   float32
-	height  float32
+	texture     *Texture
+	width       float32
+	height      float32
+	uv0         imgui.Vec2
+	uv1         imgui.Vec2
+	tintColor   color.RGBA
+	borderColor color.RGBA
 }
 
 func (i *ImageWidget) Build() {
@@ -320,15 +499,109 @@ func (i *ImageWidget) Build() {
 		if size.Y == -1 {
 			size.Y = rect.Y
 		}
-		imgui.Image(i.texture.id, size)
+		imgui.ImageV(i.texture.id, size, i.uv0, i.uv1, ToVec4Color(i.tintColor), ToVec4Color(i.borderColor))
 	}
 }
 
 func Image(texture *Texture, width, height float32) *ImageWidget {
+	return ImageV(texture, width, height, imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 1}, color.RGBA{255, 255, 255, 255}, color.RGBA{0, 0, 0, 0})
+}
+
+// ImageV shows the part of texture between uv0 and uv1 (texture coordinates from 0 to 1),
+// multiplied by tintColor and framed with borderColor.
+func ImageV(texture *Texture, width, height float32, uv0, uv1 imgui.Vec2, tintColor, borderColor color.RGBA) *ImageWidget {
 	return &ImageWidget{
-		texture: texture,
-		width:   width,
-		height:  height,
+		texture:     texture,
+		width:       width,
+		height:      height,
+		uv0:         uv0,
+		uv1:         uv1,
+		tintColor:   tintColor,
+		borderColor: borderColor,
+	}
+}
+
+type imageSourceWidget struct {
+	key         string
+	fetch       func(timeout time.Duration) ([]byte, error)
+	width       float32
+	height      float32
+	timeout     time.Duration
+	placeholder Layout
+	errorLayout Layout
+}
+
+func (i *imageSourceWidget) Build() {
+	texture, err := loadImageAsync(i.key, i.timeout, i.fetch)
+	switch {
+	case texture != nil:
+		Image(texture, i.width, i.height).Build()
+	case err != nil && i.errorLayout != nil:
+		i.errorLayout.Build()
+	case err == nil && i.placeholder != nil:
+		i.placeholder.Build()
+	default:
+		// Keep the space of the image, so the layout doesn't jump once it's loaded.
+		size := imgui.ContentRegionAvail()
+		if i.width != -1 {
+			size.X = i.width
+		}
+		if i.height != -1 {
+			size.Y = i.height
+		}
+		imgui.Dummy(size)
+	}
+}
+
+type ImageWithFileWidget struct {
+	imageSourceWidget
+}
+
+// ImageWithFile loads the image file in background, and shows it once it's loaded.
+// Textures are kept in ImageCache, so the file is loaded only once.
+func ImageWithFile(imgPath string, width, height float32) *ImageWithFileWidget {
+	return ImageWithFileV(imgPath, width, height, defaultImageLoadTimeout, nil, nil)
+}
+
+// ImageWithFileV works like ImageWithFile, placeholder is shown while the image is loading
+// and errorLayout if loading failed or took longer than timeout.
+func ImageWithFileV(imgPath string, width, height float32, timeout time.Duration, placeholder, errorLayout Layout) *ImageWithFileWidget {
+	return &ImageWithFileWidget{
+		imageSourceWidget{
+			key:         "file:" + imgPath,
+			fetch:       fetchFile(imgPath),
+			width:       width,
+			height:      height,
+			timeout:     timeout,
+			placeholder: placeholder,
+			errorLayout: errorLayout,
+		},
+	}
+}
+
+type ImageWithURLWidget struct {
+	imageSourceWidget
+}
+
+// ImageWithURL downloads the image in background, and shows it once it's loaded.
+// Textures are kept in ImageCache, so the image is downloaded only once.
+func ImageWithURL(url string, width, height float32) *ImageWithURLWidget {
+	return ImageWithURLV(url, width, height, defaultImageLoadTimeout, nil, nil)
+}
+
+// ImageWithURLV works like ImageWithURL, placeholder is shown while the image is downloading
+// and errorLayout if downloading failed or took longer than timeout.
+func ImageWithURLV(url string, width, height float32, timeout time.Duration, placeholder, errorLayout Layout) *ImageWithURLWidget {
+	return &ImageWithURLWidget{
+		imageSourceWidget{
+			key:         "url:" + url,
+			fetch:       fetchURL(url),
+			width:       width,
+			height:      height,
+			timeout:     timeout,
+			placeholder: placeholder,
+			errorLayout: errorLayout,
+		},
 	}
 }
 
@@ -365,6 +638,124 @@ func InputTextV(label string, width float32, value *string, flags InputTextFlags
 	}
 }
 
+type InputIntWidget struct {
+	label    string
+	width    float32
+	value    *int32
+	step     int
+	stepFast int
+	flags    InputTextFlags
+	changed  func()
+}
+
+func (i *InputIntWidget) Build() {
+	if i.width != 0 {
+		PushItemWidth(i.width)
+		defer PopItemWidth()
+	}
+	if imgui.InputIntV(i.label, i.value, i.step, i.stepFast, int(i.flags)) && i.changed != nil {
+		i.changed()
+	}
+}
+
+func InputInt(label string, width float32, value *int32) *InputIntWidget {
+	return InputIntV(label, width, value, 1, 100, 0, nil)
+}
+
+// InputIntV creates an input field for integers, with buttons adding step unless it's 0.
+// stepFast is added while holding ctrl.
+func InputIntV(label string, width float32, value *int32, step, stepFast int, flags InputTextFlags, changed func()) *InputIntWidget {
+	return &InputIntWidget{
+		label:    label,
+		width:    width,
+		value:    value,
+		step:     step,
+		stepFast: stepFast,
+		flags:    flags,
+		changed:  changed,
+	}
+}
+
+type InputFloatWidget struct {
+	label    string
+	width    float32
+	value    *float32
+	step     float32
+	stepFast float32
+	format   string
+	flags    InputTextFlags
+	changed  func()
+}
+
+func (i *InputFloatWidget) Build() {
+	if i.width != 0 {
+		PushItemWidth(i.width)
+		defer PopItemWidth()
+	}
+	if imgui.InputFloatV(i.label, i.value, i.step, i.stepFast, i.format, int(i.flags)) && i.changed != nil {
+		i.changed()
+	}
+}
+
+func InputFloat(label string, width float32, value *float32) *InputFloatWidget {
+	return InputFloatV(label, width, value, 0, 0, "%.3f", 0, nil)
+}
+
+// InputFloatV creates an input field for floats, with buttons adding step unless it's 0.
+// stepFast is added while holding ctrl.
+func InputFloatV(label string, width float32, value *float32, step, stepFast float32, format string, flags InputTextFlags, changed func()) *InputFloatWidget {
+	return &InputFloatWidget{
+		label:    label,
+		width:    width,
+		value:    value,
+		step:     step,
+		stepFast: stepFast,
+		format:   format,
+		flags:    flags,
+		changed:  changed,
+	}
+}
+
+type InputDoubleWidget struct {
+	label    string
+	width    float32
+	value    *float64
+	step     float64
+	stepFast float64
+	format   string
+	flags    InputTextFlags
+	changed  func()
+}
+
+func (i *InputDoubleWidget) Build() {
+	if i.width != 0 {
+		PushItemWidth(i.width)
+		defer PopItemWidth()
+	}
+	if imgui.InputDoubleV(i.label, i.value, i.step, i.stepFast, i.format, int(i.flags)) && i.changed != nil {
+		i.changed()
+	}
+}
+
+func InputDouble(label string, width float32, value *float64) *InputDoubleWidget {
+	return InputDoubleV(label, width, value, 0, 0, "%.6f", 0, nil)
+}
+
+// InputDoubleV creates an input field for doubles, with buttons adding step unless it's 0.
+// stepFast is added while holding ctrl.
+func InputDoubleV(label string, width float32, value *float64, step, stepFast float64, format string, flags InputTextFlags, changed func()) *InputDoubleWidget {
+	return &InputDoubleWidget{
+		label:    label,
+		width:    width,
+		value:    value,
+		step:     step,
+		stepFast: stepFast,
+		format:   format,
+		flags:    flags,
+		changed:  changed,
+	}
+}
+
 type LabelWidget struct {
 	label string
 	color *color.RGBA
@@ -620,6 +1011,67 @@ func SliderInt(label string, value *int32, min, max int32, format string) *Slide
 	}
 }
 
+type SliderFloatWidget struct {
+	label   string
+	value   *float32
+	min     float32
+	max     float32
+	format  string
+	changed func()
+}
+
+func (s *SliderFloatWidget) Build() {
+	if imgui.SliderFloatV(s.label, s.value, s.min, s.max, s.format, 1.0) && s.changed != nil {
+		s.changed()
+	}
+}
+
+func SliderFloat(label string, value *float32, min, max float32, format string) *SliderFloatWidget {
+	return SliderFloatV(label, value, min, max, format, nil)
+}
+
+func SliderFloatV(label string, value *float32, min, max float32, format string, changed func()) *SliderFloatWidget {
+	return &SliderFloatWidget{
+		label:   label,
+		value:   value,
+		min:     min,
+		max:     max,
+		format:  format,
+		changed: changed,
+	}
+}
+
+type SliderFloat3Widget struct {
+	label   string
+	values  *[3]float32
+	min     float32
+	max     float32
+	format  string
+	changed func()
+}
+
+func (s *SliderFloat3Widget) Build() {
+	if imgui.SliderFloat3V(s.label, s.values, s.min, s.max, s.format, 1.0) && s.changed != nil {
+		s.changed()
+	}
+}
+
+// SliderFloat3 creates three sliders in a row sharing the range, e.g. for a position or a direction.
+func SliderFloat3(label string, values *[3]float32, min, max float32, format string) *SliderFloat3Widget {
+	return SliderFloat3V(label, values, min, max, format, nil)
+}
+
+func SliderFloat3V(label string, values *[3]float32, min, max float32, format string, changed func()) *SliderFloat3Widget {
+	return &SliderFloat3Widget{
+		label:   label,
+		values:  values,
+		min:     min,
+		max:     max,
+		format:  format,
+		changed: changed,
+	}
+}
+
 type DummyWidget struct {
 	width  float32
 	height float32
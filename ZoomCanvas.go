@@ -0,0 +1,318 @@
+package giu
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AllenDang/giu/imgui"
+)
+
+const (
+	zoomCanvasMinZoom      = 1.0 / 256
+	zoomCanvasMaxZoom      = 256
+	zoomCanvasZoomStep     = 1.2
+	zoomCanvasMinGridStep  = 8
+	zoomCanvasMaxGridLines = 1000
+)
+
+// ZoomCanvasState keeps the pan offset and zoom level of a ZoomCanvas between frames.
+// The zero value shows the world origin in the upper left corner at zoom 1.
+type ZoomCanvasState struct {
+	offset imgui.Vec2 // screen position of the world origin, relative to the canvas
+	zoom   float32
+
+	// Screen rectangle and mouse state of the last frame, for the coordinate conversions.
+	origin  imgui.Vec2
+	size    imgui.Vec2
+	hovered bool
+	panning bool
+
+	// SetZoom and CenterOn before the first frame, applied once the size is known.
+	pendingZoom   float32
+	pendingCenter imgui.Vec2
+	centerPending bool
+}
+
+// Zoom returns the count of screen pixels per world unit.
+func (s *ZoomCanvasState) Zoom() float32 {
+	if s.pendingZoom > 0 {
+		return s.pendingZoom
+	}
+	if s.zoom <= 0 {
+		return 1
+	}
+	return s.zoom
+}
+
+// SetZoom zooms around the center of the canvas.
+// Before the canvas was shown, it's applied in its first frame.
+func (s *ZoomCanvasState) SetZoom(zoom float32) {
+	if s.size == (imgui.Vec2{}) {
+		s.pendingZoom = zoom
+		return
+	}
+	s.zoomAround(s.size.Times(0.5), zoom)
+}
+
+// zoomAround changes the zoom keeping the world point at anchor, relative to the canvas, in place.
+func (s *ZoomCanvasState) zoomAround(anchor imgui.Vec2, zoom float32) {
+	zoom = float32(math.Max(zoomCanvasMinZoom, math.Min(zoomCanvasMaxZoom, float64(zoom))))
+	world := anchor.Minus(s.offset).Times(1 / s.Zoom())
+	s.zoom = zoom
+	s.offset = anchor.Minus(world.Times(zoom))
+}
+
+// Offset returns the screen position of the world origin, relative to the upper left corner of the canvas.
+func (s *ZoomCanvasState) Offset() imgui.Vec2 {
+	return s.offset
+}
+
+// SetOffset pans the world origin to offset, relative to the upper left corner of the canvas.
+func (s *ZoomCanvasState) SetOffset(offset imgui.Vec2) {
+	s.offset = offset
+	s.centerPending = false
+}
+
+// CenterOn pans the world point p into the center of the canvas.
+// Before the canvas was shown, it's applied in its first frame.
+func (s *ZoomCanvasState) CenterOn(p imgui.Vec2) {
+	if s.size == (imgui.Vec2{}) {
+		s.pendingCenter, s.centerPending = p, true
+		return
+	}
+	s.offset = s.size.Times(0.5).Minus(p.Times(s.Zoom()))
+}
+
+// Reset shows the world origin in the upper left corner at zoom 1.
+func (s *ZoomCanvasState) Reset() {
+	s.offset = imgui.Vec2{}
+	s.zoom = 1
+	s.pendingZoom = 0
+	s.centerPending = false
+}
+
+// applyPending applies SetZoom and CenterOn called before the size of the canvas was known.
+func (s *ZoomCanvasState) applyPending() {
+	if zoom := s.pendingZoom; zoom > 0 {
+		s.pendingZoom = 0
+		s.zoomAround(s.size.Times(0.5), zoom)
+	}
+	if s.centerPending {
+		s.centerPending = false
+		s.CenterOn(s.pendingCenter)
+	}
+}
+
+// WorldToScreen converts a world point to screen coordinates of the last frame.
+func (s *ZoomCanvasState) WorldToScreen(p imgui.Vec2) imgui.Vec2 {
+	return s.origin.Plus(s.offset).Plus(p.Times(s.Zoom()))
+}
+
+// ScreenToWorld converts a point in screen coordinates, e.g. imgui.MousePos(), to world coordinates.
+func (s *ZoomCanvasState) ScreenToWorld(p imgui.Vec2) imgui.Vec2 {
+	return p.Minus(s.origin).Minus(s.offset).Times(1 / s.Zoom())
+}
+
+// MouseWorldPos returns the world point under the mouse cursor, ok is false if the canvas isn't hovered.
+func (s *ZoomCanvasState) MouseWorldPos() (pos imgui.Vec2, ok bool) {
+	return s.ScreenToWorld(imgui.MousePos()), s.hovered
+}
+
+// VisibleRect returns the world rectangle shown by the canvas, to skip drawing what's outside.
+func (s *ZoomCanvasState) VisibleRect() (min, max imgui.Vec2) {
+	return s.ScreenToWorld(s.origin), s.ScreenToWorld(s.origin.Plus(s.size))
+}
+
+// WorldCanvas draws on a ZoomCanvas in world coordinates.
+// Positions and radii are in world units, thicknesses stay in screen pixels at any zoom.
+type WorldCanvas struct {
+	canvas *Canvas
+	state  *ZoomCanvasState
+}
+
+func (c *WorldCanvas) toScreen(points []imgui.Vec2) []imgui.Vec2 {
+	result := make([]imgui.Vec2, len(points))
+	for i, p := range points {
+		result[i] = c.state.WorldToScreen(p)
+	}
+	return result
+}
+
+// Screen returns the underlying Canvas, to draw in screen coordinates.
+func (c *WorldCanvas) Screen() *Canvas {
+	return c.canvas
+}
+
+// State returns the state of the canvas, e.g. for coordinate conversions.
+func (c *WorldCanvas) State() *ZoomCanvasState {
+	return c.state
+}
+
+func (c *WorldCanvas) AddLine(p1, p2 imgui.Vec2, color color.RGBA, thickness float32) {
+	c.canvas.AddLineF(c.state.WorldToScreen(p1), c.state.WorldToScreen(p2), color, thickness)
+}
+
+func (c *WorldCanvas) AddRect(pMin, pMax imgui.Vec2, color color.RGBA, rounding float32, roundingCorners CornerFlags, thickness float32) {
+	c.canvas.AddRectF(c.state.WorldToScreen(pMin), c.state.WorldToScreen(pMax), color, rounding*c.state.Zoom(), roundingCorners, thickness)
+}
+
+func (c *WorldCanvas) AddRectFilled(pMin, pMax imgui.Vec2, color color.RGBA, rounding float32, roundingCorners CornerFlags) {
+	c.canvas.AddRectFilledF(c.state.WorldToScreen(pMin), c.state.WorldToScreen(pMax), color, rounding*c.state.Zoom(), roundingCorners)
+}
+
+func (c *WorldCanvas) AddCircle(center imgui.Vec2, radius float32, color color.RGBA, numSegments int, thickness float32) {
+	c.canvas.AddCircleF(c.state.WorldToScreen(center), radius*c.state.Zoom(), color, numSegments, thickness)
+}
+
+func (c *WorldCanvas) AddCircleFilled(center imgui.Vec2, radius float32, color color.RGBA, numSegments int) {
+	c.canvas.AddCircleFilledF(c.state.WorldToScreen(center), radius*c.state.Zoom(), color, numSegments)
+}
+
+func (c *WorldCanvas) AddTriangleFilled(p1, p2, p3 imgui.Vec2, color color.RGBA) {
+	c.canvas.AddTriangleFilledF(c.state.WorldToScreen(p1), c.state.WorldToScreen(p2), c.state.WorldToScreen(p3), color)
+}
+
+func (c *WorldCanvas) AddQuad(p1, p2, p3, p4 imgui.Vec2, color color.RGBA, thickness float32) {
+	c.canvas.AddQuadF(c.state.WorldToScreen(p1), c.state.WorldToScreen(p2), c.state.WorldToScreen(p3), c.state.WorldToScreen(p4), color, thickness)
+}
+
+func (c *WorldCanvas) AddQuadFilled(p1, p2, p3, p4 imgui.Vec2, color color.RGBA) {
+	c.canvas.AddQuadFilledF(c.state.WorldToScreen(p1), c.state.WorldToScreen(p2), c.state.WorldToScreen(p3), c.state.WorldToScreen(p4), color)
+}
+
+func (c *WorldCanvas) AddPolyline(points []imgui.Vec2, color color.RGBA, closed bool, thickness float32) {
+	c.canvas.AddPolylineF(c.toScreen(points), color, closed, thickness)
+}
+
+func (c *WorldCanvas) AddConvexPolyFilled(points []imgui.Vec2, color color.RGBA) {
+	c.canvas.AddConvexPolyFilledF(c.toScreen(points), color)
+}
+
+func (c *WorldCanvas) AddBezierCurve(pos0, cp0, cp1, pos1 imgui.Vec2, color color.RGBA, thickness float32, numSegments int) {
+	c.canvas.AddBezierCurveF(c.state.WorldToScreen(pos0), c.state.WorldToScreen(cp0), c.state.WorldToScreen(cp1), c.state.WorldToScreen(pos1), color, thickness, numSegments)
+}
+
+func (c *WorldCanvas) AddImage(texture *Texture, pMin, pMax imgui.Vec2) {
+	c.canvas.AddImageF(texture, c.state.WorldToScreen(pMin), c.state.WorldToScreen(pMax), imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 1}, color.RGBA{255, 255, 255, 255})
+}
+
+// AddText draws text with its upper left corner at pos, size is the font size in world units.
+func (c *WorldCanvas) AddText(pos imgui.Vec2, size float32, color color.RGBA, text string) {
+	c.canvas.AddTextVF(nil, size*c.state.Zoom(), c.state.WorldToScreen(pos), color, text, 0, nil)
+}
+
+type ZoomCanvasWidget struct {
+	id          string
+	state       *ZoomCanvasState
+	width       float32
+	height      float32
+	gridSpacing float32
+	gridColor   color.RGBA
+	draw        func(canvas *WorldCanvas)
+}
+
+// ZoomCanvas is a child widget showing an infinite world, which is panned by dragging with
+// the left or middle mouse button and zoomed around the cursor with the mouse wheel.
+// draw is invoked every frame to draw the world, the pan offset and zoom level are kept in state.
+// Width or height 0 fill the available space, like Child.
+func ZoomCanvas(id string, state *ZoomCanvasState, width, height float32, draw func(canvas *WorldCanvas)) *ZoomCanvasWidget {
+	return ZoomCanvasV(id, state, width, height, 0, color.RGBA{}, draw)
+}
+
+// ZoomCanvasV works like ZoomCanvas, and draws a grid with lines every gridSpacing world units behind the world.
+// Lines are left out when they'd get closer than a few pixels.
+func ZoomCanvasV(id string, state *ZoomCanvasState, width, height float32, gridSpacing float32, gridColor color.RGBA, draw func(canvas *WorldCanvas)) *ZoomCanvasWidget {
+	return &ZoomCanvasWidget{
+		id:          id,
+		state:       state,
+		width:       width,
+		height:      height,
+		gridSpacing: gridSpacing,
+		gridColor:   gridColor,
+		draw:        draw,
+	}
+}
+
+func (z *ZoomCanvasWidget) Build() {
+	flags := imgui.WindowFlagsNoScrollbar | imgui.WindowFlagsNoScrollWithMouse | imgui.WindowFlagsNoMove
+	if imgui.BeginChildV(z.id, imgui.Vec2{X: z.width, Y: z.height}, true, flags) {
+		z.buildContent()
+	}
+	imgui.EndChild()
+}
+
+func (z *ZoomCanvasWidget) buildContent() {
+	s := z.state
+	if s == nil {
+		return
+	}
+
+	size := imgui.ContentRegionAvail()
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+
+	s.origin = imgui.CursorScreenPos()
+	s.size = size
+	s.applyPending()
+	imgui.InvisibleButton("##zoomcanvas", size)
+	s.hovered = imgui.IsItemHovered()
+
+	mouse := imgui.MousePos().Minus(s.origin)
+	if s.hovered {
+		if _, wheel := Context.IO().GetMouseWheel(); wheel != 0 {
+			s.zoomAround(mouse, s.Zoom()*float32(math.Pow(zoomCanvasZoomStep, float64(wheel))))
+		}
+		if imgui.IsMouseClicked(int(MouseButtonMiddle)) {
+			s.panning = true
+		}
+	}
+	if s.panning && !imgui.IsMouseDown(int(MouseButtonMiddle)) {
+		s.panning = false
+	}
+	if imgui.IsItemActive() || s.panning {
+		s.offset = s.offset.Plus(Context.IO().GetMouseDelta())
+	}
+
+	canvas := &WorldCanvas{canvas: GetCanvas(), state: s}
+	canvas.canvas.PushClipRectF(s.origin, s.origin.Plus(size), true)
+	if z.gridSpacing > 0 && z.gridColor.A > 0 {
+		z.drawGrid(canvas)
+	}
+	if z.draw != nil {
+		z.draw(canvas)
+	}
+	canvas.canvas.PopClipRect()
+}
+
+func (z *ZoomCanvasWidget) drawGrid(canvas *WorldCanvas) {
+	s := z.state
+	spacing := z.gridSpacing
+	for spacing*s.Zoom() < zoomCanvasMinGridStep {
+		spacing *= 2
+	}
+
+	min, max := s.VisibleRect()
+	for _, x := range gridLines(min.X, max.X, spacing) {
+		canvas.AddLine(imgui.Vec2{X: x, Y: min.Y}, imgui.Vec2{X: x, Y: max.Y}, z.gridColor, 1)
+	}
+	for _, y := range gridLines(min.Y, max.Y, spacing) {
+		canvas.AddLine(imgui.Vec2{X: min.X, Y: y}, imgui.Vec2{X: max.X, Y: y}, z.gridColor, 1)
+	}
+}
+
+// gridLines returns the multiples of spacing from min to max, at most zoomCanvasMaxGridLines of them.
+// They're computed from an index in float64, adding up spacing in float32 stops changing large coordinates.
+func gridLines(min, max, spacing float32) []float32 {
+	step := float64(spacing)
+	first := math.Floor(float64(min)/step) * step
+	var lines []float32
+	for i := 0; i < zoomCanvasMaxGridLines; i++ {
+		v := first + float64(i)*step
+		if v > float64(max) {
+			break
+		}
+		lines = append(lines, float32(v))
+	}
+	return lines
+}
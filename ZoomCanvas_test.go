@@ -0,0 +1,30 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridLines(t *testing.T) {
+	tt := []struct {
+		name              string
+		min, max, spacing float32
+		want              []float32
+	}{
+		{name: "aligned", min: 0, max: 30, spacing: 10, want: []float32{0, 10, 20, 30}},
+		{name: "offset", min: -15, max: 12, spacing: 10, want: []float32{-20, -10, 0, 10}},
+		{name: "fraction", min: 0.1, max: 0.6, spacing: 0.25, want: []float32{0, 0.25, 0.5}},
+		{name: "large coordinates", min: 1.6e7, max: 1.6e7 + 4, spacing: 1, want: []float32{1.6e7, 1.6e7 + 1, 1.6e7 + 2, 1.6e7 + 3, 1.6e7 + 4}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			assert.Equal(t, td.want, gridLines(td.min, td.max, td.spacing))
+		})
+	}
+
+	assert.Len(t, gridLines(0, 1e6, 1), zoomCanvasMaxGridLines)
+	// Lines closer than the precision of float32 end instead of repeating forever.
+	assert.Len(t, gridLines(1.6e7, 1.6e7+100, 0.25), 401)
+}
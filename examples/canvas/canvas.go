@@ -3,8 +3,10 @@ package main
 import (
 	"image"
 	"image/color"
+	"math"
 
 	g "github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/imgui"
 )
 
 func loop() {
@@ -13,6 +15,9 @@ func loop() {
 		g.Custom(func() {
 			canvas := g.GetCanvas()
 			pos := g.GetCursorScreenPos()
+			green := color.RGBA{75, 200, 75, 255}
+			blue := color.RGBA{75, 75, 200, 255}
+			yellow := color.RGBA{200, 200, 75, 255}
 			color := color.RGBA{200, 75, 75, 255}
 			canvas.AddLine(pos, pos.Add(image.Pt(100, 100)), color, 1)
 			canvas.AddRect(pos.Add(image.Pt(110, 0)), pos.Add(image.Pt(200, 100)), color, 5, g.CornerFlags_All, 1)
@@ -41,6 +46,23 @@ func loop() {
 			canvas.PathLineTo(p2)
 			canvas.PathBezierCurveTo(p2.Add(image.Pt(40, 0)), p3.Add(image.Pt(-50, 0)), p3, 0)
 			canvas.PathStroke(color, false, 1)
+
+			// Gradient clipped to a smaller rectangle
+			p1 = pos.Add(image.Pt(250, 400))
+			p2 = pos.Add(image.Pt(450, 500))
+			canvas.PushClipRect(p1.Add(image.Pt(20, 20)), p2.Sub(image.Pt(20, 20)), true)
+			canvas.AddRectFilledMultiColor(p1, p2, color, green, blue, yellow)
+			canvas.PopClipRect()
+
+			// Smooth polyline with float coordinates
+			center := g.ToVec2(pos.Add(image.Pt(400, 270)))
+			var points []imgui.Vec2
+			for i := 0; i <= 60; i++ {
+				a := float64(i) / 60 * 2 * math.Pi
+				r := 40 + 10*math.Sin(a*5)
+				points = append(points, imgui.Vec2{X: center.X + float32(r*math.Cos(a)), Y: center.Y + float32(r*math.Sin(a))})
+			}
+			canvas.AddPolylineF(points, color, true, 1.5)
 		}),
 	})
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	g "github.com/AllenDang/giu"
+)
+
+var (
+	cpu     float64
+	history []float64
+
+	bands = []g.GaugeBand{
+		{From: 70, To: 90, Color: color.RGBA{255, 193, 7, 255}},
+		{From: 90, To: 100, Color: color.RGBA{229, 57, 53, 255}},
+	}
+)
+
+func sample() {
+	for t := 0.0; ; t += 0.1 {
+		cpu = 50 + 45*math.Sin(t)*math.Sin(t/3)
+		history = append(history, cpu)
+		if len(history) > 60 {
+			history = history[1:]
+		}
+		g.Update()
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func loop() {
+	g.SingleWindow("dashboard", g.Layout{
+		g.Line(
+			g.GaugeV(cpu, 0, 100, 160, bands, "CPU %", "%.0f"),
+			g.KPIV("Requests", "1,284", 180, 4.2, "%+.1f%%", history),
+		),
+		g.LinearMeterV(cpu, 0, 100, -1, 20, bands, "%.0f %%"),
+		g.Sparkline(history, -1, 40),
+	})
+}
+
+func main() {
+	go sample()
+
+	wnd := g.NewMasterWindow("Dashboard", 420, 320, false, nil)
+	wnd.Main(loop)
+}
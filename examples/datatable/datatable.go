@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	g "github.com/AllenDang/giu"
+)
+
+const rowCount = 100000
+
+var (
+	names  = make([]string, rowCount)
+	ages   = make([]int, rowCount)
+	scores = make([]float64, rowCount)
+
+	state    g.DataTableState
+	selected string
+)
+
+func init() {
+	first := []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken"}
+	for i := range names {
+		names[i] = fmt.Sprintf("%s %d", first[rand.Intn(len(first))], i)
+		ages[i] = 18 + rand.Intn(60)
+		scores[i] = rand.Float64() * 100
+	}
+}
+
+func onSelectionChange(rows []int) {
+	selected = fmt.Sprintf("%d rows selected", len(rows))
+}
+
+func reset(row int) g.Widget {
+	return g.Button("Reset", func() {
+		scores[row] = 0
+		state.Refresh()
+	})
+}
+
+func loop() {
+	age := g.IntColumn("Age", ages)
+	age.Width = 60
+
+	g.SingleWindow("data table", g.Layout{
+		g.Label(selected),
+		g.DataTableV("people", &state, rowCount, -1, -1, g.DataTableSelectMulti, onSelectionChange,
+			g.TextColumn("Name", names),
+			age,
+			g.FloatColumn("Score", scores, "%.2f"),
+			g.WidgetColumn("", reset),
+		),
+	})
+}
+
+func main() {
+	wnd := g.NewMasterWindow("Data table", 640, 480, true, nil)
+	wnd.Main(loop)
+}
@@ -1,27 +1,37 @@
 package main
 
 import (
+	"io/ioutil"
+
 	g "github.com/AllenDang/giu"
-	"github.com/AllenDang/giu/imgui"
 )
 
-func loadFont() {
-	fonts := g.Context.IO().Fonts()
-
-	ranges := imgui.NewGlyphRanges()
+const text = "你好啊世界！铁憨憨"
 
-	builder := imgui.NewFontGlyphRangesBuilder()
-	builder.AddText("铁憨憨你好！")
-	// builder.AddRanges(fonts.GlyphRangesChineseFull())
-	builder.BuildRanges(ranges)
+var fontSize int32 = 12
 
+func loadFont() {
 	fontPath := "/System/Library/Fonts/STHeiti Light.ttc"
-	fonts.AddFontFromFileTTFV(fontPath, 12, imgui.DefaultFontConfig, ranges.Data())
+	ttf, err := ioutil.ReadFile(fontPath)
+	if err != nil {
+		panic(err)
+	}
+
+	// Only glyphs of registered text will be baked into the font atlas.
+	g.Fonts.RegisterString(text)
+
+	g.Fonts.Register("heiti", ttf, float32(fontSize))
+	g.Fonts.SetDefault("heiti")
 }
 
 func loop() {
 	g.SingleWindow("dynamic load font", g.Layout{
-		g.Label("你好啊世界！铁憨憨"),
+		g.Label(text),
+		g.SliderInt("Font size", &fontSize, 8, 48, "%d"),
+		g.Button("Apply", func() {
+			// The font atlas will be rebuilt before next frame.
+			g.Fonts.SetSize("heiti", float32(fontSize))
+		}),
 	})
 }
 
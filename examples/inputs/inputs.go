@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	g "github.com/AllenDang/giu"
+)
+
+var (
+	count    int32 = 3
+	ratio    float32
+	price            = 9.99
+	speed    float32 = 0.5
+	position [3]float32
+	fruit    int32
+	fruits   = []string{"Apple", "Banana", "Cherry", "Durian"}
+	tint     = color.RGBA{66, 150, 250, 255}
+	shade    = color.RGBA{40, 40, 40, 128}
+	status   string
+)
+
+func changed(name string) func() {
+	return func() { status = name + " changed" }
+}
+
+func loop() {
+	g.SingleWindow("inputs", g.Layout{
+		g.InputIntV("Count", 120, &count, 1, 10, 0, changed("Count")),
+		g.InputFloat("Ratio", 120, &ratio),
+		g.InputDoubleV("Price", 120, &price, 0.01, 1, "%.2f", 0, changed("Price")),
+		g.DragFloatV("Speed", &speed, 0.01, 0, 1, "%.2f", changed("Speed")),
+		g.SliderFloat3V("Position", &position, -10, 10, "%.1f", changed("Position")),
+		g.ListBoxV("Fruit", fruits, &fruit, 4, changed("Fruit")),
+		g.ColorEditV("Tint", &tint, 0, changed("Tint")),
+		g.ColorEdit3V("Shade", &shade, 0, changed("Shade")),
+		g.Label(fmt.Sprintf("%s, tint %v, shade %v", status, tint, shade)),
+	})
+}
+
+func main() {
+	wnd := g.NewMasterWindow("Inputs", 480, 360, true, nil)
+	wnd.Main(loop)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	g "github.com/AllenDang/giu"
+)
+
+var (
+	lines    = make([]string, 1000000)
+	state    g.ListViewState
+	selected = "Use the arrow keys to navigate"
+)
+
+func init() {
+	for i := range lines {
+		lines[i] = fmt.Sprintf("[%07d] log line", i)
+	}
+}
+
+func onSelect(index int) {
+	selected = lines[index]
+}
+
+func item(i int) g.Widget {
+	return g.Label(lines[i])
+}
+
+func loop() {
+	g.SingleWindow("list view", g.Layout{
+		g.Line(
+			g.Label(selected),
+			g.Button("Go to middle", func() {
+				state.SetSelected(len(lines) / 2)
+				state.ScrollTo(len(lines) / 2)
+				onSelect(len(lines) / 2)
+			}),
+		),
+		g.ListViewV("lines", &state, len(lines), 0, -1, -1, onSelect, item),
+	})
+}
+
+func main() {
+	wnd := g.NewMasterWindow("List view", 480, 400, true, nil)
+	wnd.Main(loop)
+}
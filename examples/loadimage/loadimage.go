@@ -1,12 +1,7 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
-	"image"
-	"image/draw"
-	_ "image/jpeg"
-	_ "image/png"
 	"time"
 
 	g "github.com/AllenDang/giu"
@@ -26,10 +21,8 @@ func loadImage(imageUrl string) {
 
 	resp, err := client.R().Get(imageUrl)
 	if err == nil {
-		img, _, err := image.Decode(bytes.NewReader(resp.Body()))
+		rgba, err := g.LoadImageFromBytes(resp.Body())
 		if err == nil {
-			rgba := image.NewRGBA(img.Bounds())
-			draw.Draw(rgba, img.Bounds(), img, image.Point{}, draw.Src)
 			texture, _ = g.NewTextureFromRgba(rgba)
 		} else {
 			fmt.Println(err)
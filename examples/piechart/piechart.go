@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	g "github.com/AllenDang/giu"
+)
+
+var (
+	selected = "Click a slice"
+
+	browsers = []g.PieSlice{
+		{Label: "Chrome", Value: 64.7},
+		{Label: "Safari", Value: 18.6},
+		{Label: "Edge", Value: 5.1},
+		{Label: "Firefox", Value: 3.0},
+		{Label: "Other", Value: 8.6},
+	}
+)
+
+func onClick(index int) {
+	selected = fmt.Sprintf("%s: %.1f", browsers[index].Label, browsers[index].Value)
+}
+
+func loop() {
+	g.SingleWindow("pie chart", g.Layout{
+		g.Label(selected),
+		g.Line(
+			g.PieChartV("pie", browsers, 160, 0, true, true, onClick),
+			g.PieChartV("donut", browsers, 160, 0.5, true, false, onClick),
+		),
+	})
+}
+
+func main() {
+	wnd := g.NewMasterWindow("Pie chart", 760, 280, false, nil)
+	wnd.Main(loop)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	g "github.com/AllenDang/giu"
+)
+
+// files provides the directories and files below root, nodes are identified by their paths.
+type files struct {
+	root string
+}
+
+func (f files) Children(id string) ([]g.TreeViewNode, error) {
+	if id == "" {
+		id = f.root
+	}
+	infos, err := ioutil.ReadDir(id)
+	if err != nil {
+		return nil, err
+	}
+	// Directories first.
+	sort.SliceStable(infos, func(i, j int) bool { return infos[i].IsDir() && !infos[j].IsDir() })
+
+	nodes := make([]g.TreeViewNode, len(infos))
+	for i, info := range infos {
+		nodes[i] = g.TreeViewNode{
+			ID:    filepath.Join(id, info.Name()),
+			Label: info.Name(),
+			Leaf:  !info.IsDir(),
+		}
+	}
+	return nodes, nil
+}
+
+var (
+	state    g.TreeViewState
+	selected []string
+)
+
+func onSelectionChange(ids []string) {
+	selected = ids
+}
+
+func contextMenu(node g.TreeViewNode) g.Layout {
+	return g.Layout{
+		g.Selectable("Reload", func() { state.Reload(node.ID) }),
+	}
+}
+
+func loop() {
+	label := "Nothing selected"
+	if len(selected) > 0 {
+		label = selected[0]
+	}
+	g.SingleWindow("tree view", g.Layout{
+		g.Label(label),
+		g.TreeViewV("files", &state, files{root: "."}, -1, -1, true, true, onSelectionChange, contextMenu),
+	})
+}
+
+func main() {
+	wnd := g.NewMasterWindow("Tree view", 480, 480, true, nil)
+	wnd.Main(loop)
+}
@@ -115,6 +115,17 @@ func (list DrawList) AddText(pos Vec2, col Vec4, text string) {
 	C.iggDrawListAddText(list.handle(), posArg, C.uint(c), textArg)
 }
 
+// AddTextV draws text with font at fontSize, DefaultFont and 0 use the current font and its size.
+// Text is wrapped at wrapWidth if it's > 0, and glyphs are clipped to cpuFineClipRect (minX, minY, maxX, maxY) if it's not nil.
+func (list DrawList) AddTextV(font Font, fontSize float32, pos Vec2, col Vec4, text string, wrapWidth float32, cpuFineClipRect *Vec4) {
+	c := GetColorU32(col)
+	posArg, _ := pos.wrapped()
+	clipRectArg, _ := cpuFineClipRect.wrapped()
+	textArg, textFin := wrapString(text)
+	defer textFin()
+	C.iggDrawListAddTextV(list.handle(), font.handle(), C.float(fontSize), posArg, C.uint(c), textArg, C.float(wrapWidth), clipRectArg)
+}
+
 func (list DrawList) AddBezierCurve(pos0, cp0, cp1, pos1 Vec2, col Vec4, thickness float32, num_segments int) {
 	c := GetColorU32(col)
 	pos0Arg, _ := pos0.wrapped()
@@ -170,6 +181,113 @@ func (list DrawList) AddQuadFilled(p1, p2, p3, p4 Vec2, col Vec4) {
 	C.iggDrawListAddQuadFilled(list.handle(), p1Arg, p2Arg, p3Arg, p4Arg, C.uint(c))
 }
 
+// AddImage draws the part of texture id between uvMin and uvMax into the rectangle pMin-pMax, tinted with col.
+func (list DrawList) AddImage(id TextureID, pMin, pMax, uvMin, uvMax Vec2, col Vec4) {
+	c := GetColorU32(col)
+	pMinArg, _ := pMin.wrapped()
+	pMaxArg, _ := pMax.wrapped()
+	uvMinArg, _ := uvMin.wrapped()
+	uvMaxArg, _ := uvMax.wrapped()
+	C.iggDrawListAddImage(list.handle(), id.handle(), pMinArg, pMaxArg, uvMinArg, uvMaxArg, C.uint(c))
+}
+
+// AddImageQuad draws texture id into the quad p1-p4, uv1-uv4 are the texture coordinates of its corners.
+func (list DrawList) AddImageQuad(id TextureID, p1, p2, p3, p4, uv1, uv2, uv3, uv4 Vec2, col Vec4) {
+	c := GetColorU32(col)
+	p1Arg, _ := p1.wrapped()
+	p2Arg, _ := p2.wrapped()
+	p3Arg, _ := p3.wrapped()
+	p4Arg, _ := p4.wrapped()
+	uv1Arg, _ := uv1.wrapped()
+	uv2Arg, _ := uv2.wrapped()
+	uv3Arg, _ := uv3.wrapped()
+	uv4Arg, _ := uv4.wrapped()
+	C.iggDrawListAddImageQuad(list.handle(), id.handle(), p1Arg, p2Arg, p3Arg, p4Arg, uv1Arg, uv2Arg, uv3Arg, uv4Arg, C.uint(c))
+}
+
+// AddPolyline draws lines through points, closed connects the last point with the first one.
+func (list DrawList) AddPolyline(points []Vec2, col Vec4, closed bool, thickness float32) {
+	if len(points) < 2 {
+		return
+	}
+	c := GetColorU32(col)
+	C.iggDrawListAddPolyline(list.handle(), (*C.IggVec2)(unsafe.Pointer(&points[0])), C.int(len(points)), C.uint(c), castBool(closed), C.float(thickness))
+}
+
+// AddConvexPolyFilled fills the convex polygon described by points.
+func (list DrawList) AddConvexPolyFilled(points []Vec2, col Vec4) {
+	if len(points) < 3 {
+		return
+	}
+	c := GetColorU32(col)
+	C.iggDrawListAddConvexPolyFilled(list.handle(), (*C.IggVec2)(unsafe.Pointer(&points[0])), C.int(len(points)), C.uint(c))
+}
+
+// AddTriangles fills the triangles of points, taken three at a time, as one mesh.
+// Unlike other filled shapes the edges aren't anti-aliased, so no seams show between triangles sharing an edge.
+func (list DrawList) AddTriangles(points []Vec2, col Vec4) {
+	count := len(points) - len(points)%3
+	if count == 0 {
+		return
+	}
+	c := GetColorU32(col)
+	C.iggDrawListAddTriangles(list.handle(), (*C.IggVec2)(unsafe.Pointer(&points[0])), C.int(count), C.uint(c))
+}
+
+// AddRectFilledMultiColor fills a rectangle with a gradient between the colors of its corners.
+func (list DrawList) AddRectFilledMultiColor(pMin, pMax Vec2, colUpperLeft, colUpperRight, colBottomRight, colBottomLeft Vec4) {
+	pMinArg, _ := pMin.wrapped()
+	pMaxArg, _ := pMax.wrapped()
+	C.iggDrawListAddRectFilledMultiColor(list.handle(), pMinArg, pMaxArg,
+		C.uint(GetColorU32(colUpperLeft)), C.uint(GetColorU32(colUpperRight)),
+		C.uint(GetColorU32(colBottomRight)), C.uint(GetColorU32(colBottomLeft)))
+}
+
+// PushClipRect clips following primitives to the rectangle clipRectMin-clipRectMax, until PopClipRect is called.
+// intersectWithCurrentClipRect limits it to the current clip rectangle, e.g. the one of the window.
+func (list DrawList) PushClipRect(clipRectMin, clipRectMax Vec2, intersectWithCurrentClipRect bool) {
+	minArg, _ := clipRectMin.wrapped()
+	maxArg, _ := clipRectMax.wrapped()
+	C.iggDrawListPushClipRect(list.handle(), minArg, maxArg, castBool(intersectWithCurrentClipRect))
+}
+
+// PushClipRectFullScreen lifts clipping, until PopClipRect is called.
+func (list DrawList) PushClipRectFullScreen() {
+	C.iggDrawListPushClipRectFullScreen(list.handle())
+}
+
+// PopClipRect restores the clip rectangle before the last PushClipRect.
+func (list DrawList) PopClipRect() {
+	C.iggDrawListPopClipRect(list.handle())
+}
+
+// ChannelsSplit splits drawing into count channels, which are drawn in order by ChannelsMerge.
+// It allows drawing out of order, e.g. backgrounds after the content they are behind.
+func (list DrawList) ChannelsSplit(count int) {
+	C.iggDrawListChannelsSplit(list.handle(), C.int(count))
+}
+
+// ChannelsMerge merges the channels created by ChannelsSplit.
+func (list DrawList) ChannelsMerge() {
+	C.iggDrawListChannelsMerge(list.handle())
+}
+
+// ChannelsSetCurrent selects the channel following primitives are added to.
+func (list DrawList) ChannelsSetCurrent(n int) {
+	C.iggDrawListChannelsSetCurrent(list.handle(), C.int(n))
+}
+
+// VertexCount returns the count of vertices in the list, take it before adding primitives to transform them afterwards.
+func (list DrawList) VertexCount() int {
+	return int(C.iggDrawListGetVertexCount(list.handle()))
+}
+
+// RotateVertices rotates the vertices from start on by angle (radians, clockwise on screen) around center.
+func (list DrawList) RotateVertices(start int, angle float32, center Vec2) {
+	centerArg, _ := center.wrapped()
+	C.iggDrawListRotateVertices(list.handle(), C.int(start), C.float(angle), centerArg)
+}
+
 // Stateful path API, add points then finish with PathFillConvex() or PathStroke()
 func (list DrawList) PathClear() {
 	C.iggDrawListPathClear(list.handle())
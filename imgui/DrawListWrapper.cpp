@@ -1,6 +1,7 @@
 #include "imguiWrappedHeader.h"
 #include "DrawListWrapper.h"
 #include "WrapperConverter.h"
+#include <math.h>
 
 int iggDrawListGetCommandCount(IggDrawList handle)
 {
@@ -72,6 +73,14 @@ void iggDrawListAddText(IggDrawList handle, IggVec2 *pos, unsigned int col, cons
   list->AddText(*posArg, col, text);
 }
 
+void iggDrawListAddTextV(IggDrawList handle, IggFont font, float font_size, IggVec2 *pos, unsigned int col, const char *text, float wrap_width, IggVec4 *cpu_fine_clip_rect)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  Vec2Wrapper posArg(pos);
+  Vec4Wrapper clipRectArg(cpu_fine_clip_rect);
+  list->AddText(reinterpret_cast<ImFont*>(font), font_size, *posArg, col, text, nullptr, wrap_width, clipRectArg);
+}
+
 void iggDrawListAddBezierCurve(IggDrawList handle, IggVec2 *pos0, IggVec2 *cp0, IggVec2 *cp1, IggVec2 *pos1, unsigned int col, float thickness, int num_segments)
 {
   ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
@@ -134,6 +143,122 @@ void iggDrawListAddQuadFilled(IggDrawList handle, IggVec2 *p1, IggVec2 *p2, IggV
   list->AddQuadFilled(*p1Arg, *p2Arg, *p3Arg, *p4Arg, col);
 }
 
+void iggDrawListAddImage(IggDrawList handle, IggTextureID textureID, IggVec2 *p_min, IggVec2 *p_max, IggVec2 *uv_min, IggVec2 *uv_max, unsigned int col)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  Vec2Wrapper pMinArg(p_min);
+  Vec2Wrapper pMaxArg(p_max);
+  Vec2Wrapper uvMinArg(uv_min);
+  Vec2Wrapper uvMaxArg(uv_max);
+  list->AddImage(static_cast<ImTextureID>(textureID), *pMinArg, *pMaxArg, *uvMinArg, *uvMaxArg, col);
+}
+
+void iggDrawListAddImageQuad(IggDrawList handle, IggTextureID textureID, IggVec2 *p1, IggVec2 *p2, IggVec2 *p3, IggVec2 *p4, IggVec2 *uv1, IggVec2 *uv2, IggVec2 *uv3, IggVec2 *uv4, unsigned int col)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  Vec2Wrapper p1Arg(p1);
+  Vec2Wrapper p2Arg(p2);
+  Vec2Wrapper p3Arg(p3);
+  Vec2Wrapper p4Arg(p4);
+  Vec2Wrapper uv1Arg(uv1);
+  Vec2Wrapper uv2Arg(uv2);
+  Vec2Wrapper uv3Arg(uv3);
+  Vec2Wrapper uv4Arg(uv4);
+  list->AddImageQuad(static_cast<ImTextureID>(textureID), *p1Arg, *p2Arg, *p3Arg, *p4Arg, *uv1Arg, *uv2Arg, *uv3Arg, *uv4Arg, col);
+}
+
+void iggDrawListAddPolyline(IggDrawList handle, IggVec2 *points, int count, unsigned int col, IggBool closed, float thickness)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  list->AddPolyline(reinterpret_cast<ImVec2*>(points), count, col, closed != 0, thickness);
+}
+
+void iggDrawListAddConvexPolyFilled(IggDrawList handle, IggVec2 *points, int count, unsigned int col)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  list->AddConvexPolyFilled(reinterpret_cast<ImVec2*>(points), count, col);
+}
+
+void iggDrawListAddTriangles(IggDrawList handle, IggVec2 *points, int count, unsigned int col)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  ImVec2 *p = reinterpret_cast<ImVec2*>(points);
+  ImVec2 uv = ImGui::GetFontTexUvWhitePixel();
+  list->PrimReserve(count, count);
+  for (int i = 0; i < count; i++)
+  {
+    list->PrimWriteIdx((ImDrawIdx)list->_VtxCurrentIdx);
+    list->PrimWriteVtx(p[i], uv, col);
+  }
+}
+
+void iggDrawListAddRectFilledMultiColor(IggDrawList handle, IggVec2 *p_min, IggVec2 *p_max, unsigned int col_upr_left, unsigned int col_upr_right, unsigned int col_bot_right, unsigned int col_bot_left)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  Vec2Wrapper pMinArg(p_min);
+  Vec2Wrapper pMaxArg(p_max);
+  list->AddRectFilledMultiColor(*pMinArg, *pMaxArg, col_upr_left, col_upr_right, col_bot_right, col_bot_left);
+}
+
+void iggDrawListPushClipRect(IggDrawList handle, IggVec2 *clip_rect_min, IggVec2 *clip_rect_max, IggBool intersect_with_current_clip_rect)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  Vec2Wrapper minArg(clip_rect_min);
+  Vec2Wrapper maxArg(clip_rect_max);
+  list->PushClipRect(*minArg, *maxArg, intersect_with_current_clip_rect != 0);
+}
+
+void iggDrawListPushClipRectFullScreen(IggDrawList handle)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  list->PushClipRectFullScreen();
+}
+
+void iggDrawListPopClipRect(IggDrawList handle)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  list->PopClipRect();
+}
+
+void iggDrawListChannelsSplit(IggDrawList handle, int count)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  list->ChannelsSplit(count);
+}
+
+void iggDrawListChannelsMerge(IggDrawList handle)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  list->ChannelsMerge();
+}
+
+void iggDrawListChannelsSetCurrent(IggDrawList handle, int n)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  list->ChannelsSetCurrent(n);
+}
+
+int iggDrawListGetVertexCount(IggDrawList handle)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  return list->VtxBuffer.Size;
+}
+
+void iggDrawListRotateVertices(IggDrawList handle, int start, float angle, IggVec2 *center)
+{
+  ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
+  Vec2Wrapper centerArg(center);
+  ImVec2 pivot = *centerArg;
+  float s = sinf(angle);
+  float c = cosf(angle);
+  for (int i = start; i < list->VtxBuffer.Size; i++)
+  {
+    ImVec2 &p = list->VtxBuffer[i].pos;
+    ImVec2 d(p.x - pivot.x, p.y - pivot.y);
+    p = ImVec2(pivot.x + d.x * c - d.y * s, pivot.y + d.x * s + d.y * c);
+  }
+}
+
 void iggDrawListPathClear(IggDrawList handle)
 {
   ImDrawList *list = reinterpret_cast<ImDrawList*>(handle);
@@ -19,6 +19,7 @@ extern void iggDrawListAddLine(IggDrawList handle, IggVec2 *p1, IggVec2 *p2, uns
 extern void iggDrawListAddRect(IggDrawList handle, IggVec2 *p_min, IggVec2 *p_max, unsigned int col, float rounding, int rounding_corners, float thickness);
 extern void iggDrawListAddRectFilled(IggDrawList handle, IggVec2 *p_min, IggVec2 *p_max, unsigned int col, float rounding, int rounding_corners);
 extern void iggDrawListAddText(IggDrawList handle, IggVec2 *pos, unsigned int col, const char *text);
+extern void iggDrawListAddTextV(IggDrawList handle, IggFont font, float font_size, IggVec2 *pos, unsigned int col, const char *text, float wrap_width, IggVec4 *cpu_fine_clip_rect);
 extern void iggDrawListAddBezierCurve(IggDrawList handle, IggVec2 *pos0, IggVec2 *cp0, IggVec2 *cp1, IggVec2 *pos1, unsigned int col, float thickness, int num_segments);
 extern void iggDrawListAddTriangle(IggDrawList handle, IggVec2 *p1, IggVec2 *p2, IggVec2 *p3, unsigned int col, float thickness);
 extern void iggDrawListAddTriangleFilled(IggDrawList handle, IggVec2 *p1, IggVec2 *p2, IggVec2 *p3, unsigned int col);
@@ -26,6 +27,23 @@ extern void iggDrawListAddCircle(IggDrawList handle, IggVec2 *center, float radi
 extern void iggDrawListAddCircleFilled(IggDrawList handle, IggVec2 *center, float radius, unsigned int col, int num_segments);
 extern void iggDrawListAddQuad(IggDrawList handle, IggVec2 *p1, IggVec2 *p2, IggVec2 *p3, IggVec2 *p4, unsigned int col, float thickness);
 extern void iggDrawListAddQuadFilled(IggDrawList handle, IggVec2 *p1, IggVec2 *p2, IggVec2 *p3, IggVec2 *p4, unsigned int col);
+extern void iggDrawListAddImage(IggDrawList handle, IggTextureID textureID, IggVec2 *p_min, IggVec2 *p_max, IggVec2 *uv_min, IggVec2 *uv_max, unsigned int col);
+extern void iggDrawListAddImageQuad(IggDrawList handle, IggTextureID textureID, IggVec2 *p1, IggVec2 *p2, IggVec2 *p3, IggVec2 *p4, IggVec2 *uv1, IggVec2 *uv2, IggVec2 *uv3, IggVec2 *uv4, unsigned int col);
+extern void iggDrawListAddPolyline(IggDrawList handle, IggVec2 *points, int count, unsigned int col, IggBool closed, float thickness);
+extern void iggDrawListAddConvexPolyFilled(IggDrawList handle, IggVec2 *points, int count, unsigned int col);
+extern void iggDrawListAddTriangles(IggDrawList handle, IggVec2 *points, int count, unsigned int col);
+extern void iggDrawListAddRectFilledMultiColor(IggDrawList handle, IggVec2 *p_min, IggVec2 *p_max, unsigned int col_upr_left, unsigned int col_upr_right, unsigned int col_bot_right, unsigned int col_bot_left);
+
+extern void iggDrawListPushClipRect(IggDrawList handle, IggVec2 *clip_rect_min, IggVec2 *clip_rect_max, IggBool intersect_with_current_clip_rect);
+extern void iggDrawListPushClipRectFullScreen(IggDrawList handle);
+extern void iggDrawListPopClipRect(IggDrawList handle);
+
+extern void iggDrawListChannelsSplit(IggDrawList handle, int count);
+extern void iggDrawListChannelsMerge(IggDrawList handle);
+extern void iggDrawListChannelsSetCurrent(IggDrawList handle, int n);
+
+extern int iggDrawListGetVertexCount(IggDrawList handle);
+extern void iggDrawListRotateVertices(IggDrawList handle, int start, float angle, IggVec2 *center);
 
 extern void iggDrawListPathClear(IggDrawList handle);
 extern void iggDrawListPathLineTo(IggDrawList handle, IggVec2 *pos);
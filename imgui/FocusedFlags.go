@@ -0,0 +1,14 @@
+package imgui
+
+const (
+	// FocusedFlagsNone returns true if the current window is focused.
+	FocusedFlagsNone = 0
+	// FocusedFlagsChildWindows returns true if any children of the window is focused.
+	FocusedFlagsChildWindows = 1 << 0
+	// FocusedFlagsRootWindow tests from the root window (top most parent of the current hierarchy).
+	FocusedFlagsRootWindow = 1 << 1
+	// FocusedFlagsAnyWindow returns true if any window is focused.
+	FocusedFlagsAnyWindow = 1 << 2
+	// FocusedFlagsRootAndChildWindows combines FocusedFlagsRootWindow and FocusedFlagsChildWindows.
+	FocusedFlagsRootAndChildWindows = FocusedFlagsRootWindow | FocusedFlagsChildWindows
+)
@@ -1,6 +1,6 @@
 package imgui
 
-// #include "imguiWrapperTypes.h"
+// #include "FontWrapper.h"
 import "C"
 
 // Font describes one loaded font in an atlas.
@@ -13,3 +13,11 @@ const DefaultFont Font = 0
 func (font Font) handle() C.IggFont {
 	return C.IggFont(font)
 }
+
+// SetScale sets the base scale the font is drawn with, multiplied by the window font scale.
+// Has no effect on DefaultFont.
+func (font Font) SetScale(scale float32) {
+	if font != DefaultFont {
+		C.iggFontSetScale(font.handle(), C.float(scale))
+	}
+}
@@ -118,6 +118,28 @@ func (atlas FontAtlas) AddFontFromMemoryTTF(fontData []byte, sizePixels float32)
 	return atlas.AddFontFromMemoryTTFV(fontData, sizePixels, DefaultFontConfig, EmptyGlyphRanges)
 }
 
+// Clear removes all fonts, input data and texture data of the atlas.
+// All Font references of the atlas become invalid.
+func (atlas FontAtlas) Clear() {
+	C.iggFontAtlasClear(atlas.handle())
+}
+
+// Build builds the texture data with the default rasterizer.
+// Returns false if the atlas could not be built.
+func (atlas FontAtlas) Build() bool {
+	return C.iggFontAtlasBuild(atlas.handle()) != 0
+}
+
+// IsBuilt returns true if the atlas contains fonts and its texture data has been built.
+func (atlas FontAtlas) IsBuilt() bool {
+	return C.iggFontAtlasIsBuilt(atlas.handle()) != 0
+}
+
+// FontCount returns the number of fonts added to the atlas, fonts merged into others aren't counted.
+func (atlas FontAtlas) FontCount() int {
+	return int(C.iggFontAtlasFontCount(atlas.handle()))
+}
+
 // SetTexDesiredWidth registers the width desired by user before building the image. Must be a power-of-two.
 // If have many glyphs your graphics API have texture size restrictions you may want to increase texture width to decrease height.
 // Set to 0 by default, causing auto-calculation.
@@ -162,3 +184,8 @@ func (atlas FontAtlas) TextureDataRGBA32() *RGBA32Image {
 func (atlas FontAtlas) SetTextureID(id TextureID) {
 	C.iggFontAtlasSetTextureID(atlas.handle(), id.handle())
 }
+
+// TextureID returns the user data set by SetTextureID.
+func (atlas FontAtlas) TextureID() TextureID {
+	return TextureID(C.iggFontAtlasGetTextureID(atlas.handle()))
+}
@@ -79,6 +79,29 @@ IggFont iggAddFontFromMemoryTTF(IggFontAtlas handle, char *font_data, int font_s
    return static_cast<IggFont>(font);
 }
 
+void iggFontAtlasClear(IggFontAtlas handle)
+{
+   ImFontAtlas *fontAtlas = reinterpret_cast<ImFontAtlas *>(handle);
+   fontAtlas->Clear();
+}
+
+IggBool iggFontAtlasBuild(IggFontAtlas handle)
+{
+   ImFontAtlas *fontAtlas = reinterpret_cast<ImFontAtlas *>(handle);
+   return fontAtlas->Build() ? 1 : 0;
+}
+
+IggBool iggFontAtlasIsBuilt(IggFontAtlas handle)
+{
+   ImFontAtlas *fontAtlas = reinterpret_cast<ImFontAtlas *>(handle);
+   return fontAtlas->IsBuilt() ? 1 : 0;
+}
+
+int iggFontAtlasFontCount(IggFontAtlas handle)
+{
+   ImFontAtlas *fontAtlas = reinterpret_cast<ImFontAtlas *>(handle);
+   return fontAtlas->Fonts.Size;
+}
 
 void iggFontAtlasSetTexDesiredWidth(IggFontAtlas handle, int value)
 {
@@ -105,3 +128,9 @@ void iggFontAtlasSetTextureID(IggFontAtlas handle, IggTextureID id)
    ImFontAtlas *fontAtlas = reinterpret_cast<ImFontAtlas *>(handle);
    fontAtlas->SetTexID(id);
 }
+
+IggTextureID iggFontAtlasGetTextureID(IggFontAtlas handle)
+{
+   ImFontAtlas *fontAtlas = reinterpret_cast<ImFontAtlas *>(handle);
+   return fontAtlas->TexID;
+}
@@ -23,6 +23,11 @@ extern IggFont iggAddFontFromMemoryTTF(IggFontAtlas handle, char *font_data, int
 		IggFontConfig config, IggGlyphRanges glyphRanges);
 
 
+extern void iggFontAtlasClear(IggFontAtlas handle);
+extern IggBool iggFontAtlasBuild(IggFontAtlas handle);
+extern IggBool iggFontAtlasIsBuilt(IggFontAtlas handle);
+extern int iggFontAtlasFontCount(IggFontAtlas handle);
+
 extern void iggFontAtlasSetTexDesiredWidth(IggFontAtlas handle, int value);
 
 extern void iggFontAtlasGetTexDataAsAlpha8(IggFontAtlas handle, unsigned char **pixels,
@@ -30,6 +35,7 @@ extern void iggFontAtlasGetTexDataAsAlpha8(IggFontAtlas handle, unsigned char **
 extern void iggFontAtlasGetTexDataAsRGBA32(IggFontAtlas handle, unsigned char **pixels,
       int *width, int *height, int *bytesPerPixel);
 extern void iggFontAtlasSetTextureID(IggFontAtlas handle, IggTextureID id);
+extern IggTextureID iggFontAtlasGetTextureID(IggFontAtlas handle);
 
 #ifdef __cplusplus
 }
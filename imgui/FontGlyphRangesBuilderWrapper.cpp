@@ -11,6 +11,11 @@ IggGlyphRanges IggGlyphRangesData(IggGlyphRanges handle) {
   return static_cast<IggGlyphRanges>(ranges->Data);
 }
 
+void IggDeleteGlyphRanges(IggGlyphRanges handle) {
+  ImVector<ImWchar> *ranges = reinterpret_cast<ImVector<ImWchar>*>(handle);
+  delete ranges;
+}
+
 IggFontGlyphRangesBuilder IggNewFontGlyphRangesBuilder()
 {
   ImFontGlyphRangesBuilder *builder = new ImFontGlyphRangesBuilder();
@@ -23,6 +28,12 @@ void IggFontGlyphRangesBuilderClear(IggFontGlyphRangesBuilder handle)
   builder->Clear();
 }
 
+void IggFontGlyphRangesBuilderAddChar(IggFontGlyphRangesBuilder handle, unsigned short c)
+{
+  ImFontGlyphRangesBuilder *builder = reinterpret_cast<ImFontGlyphRangesBuilder*>(handle);
+  builder->AddChar(static_cast<ImWchar>(c));
+}
+
 void IggFontGlyphRangesBuilderAddText(IggFontGlyphRangesBuilder handle, const char* text)
 {
   ImFontGlyphRangesBuilder *builder = reinterpret_cast<ImFontGlyphRangesBuilder*>(handle);
@@ -9,10 +9,12 @@ extern "C"
 
 extern IggGlyphRanges IggNewGlyphRanges();
 extern IggGlyphRanges IggGlyphRangesData(IggGlyphRanges handle);
+extern void IggDeleteGlyphRanges(IggGlyphRanges handle);
 
 extern IggFontGlyphRangesBuilder IggNewFontGlyphRangesBuilder();
 extern void IggFontGlyphRangesBuilderClear(IggFontGlyphRangesBuilder handle);
 extern void IggFontGlyphRangesBuilderAddRanges(IggFontGlyphRangesBuilder handle, IggGlyphRanges ranges);
+extern void IggFontGlyphRangesBuilderAddChar(IggFontGlyphRangesBuilder handle, unsigned short c);
 extern void IggFontGlyphRangesBuilderAddText(IggFontGlyphRangesBuilder handle, const char* text);
 extern void IggFontGlyphRangesBuilderBuildRanges(IggFontGlyphRangesBuilder handle, IggGlyphRanges ranges);
 
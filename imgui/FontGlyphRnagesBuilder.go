@@ -14,6 +14,12 @@ func (ranges GlyphRanges) Data() GlyphRanges {
 	return GlyphRanges(C.IggGlyphRangesData(ranges.handle()))
 }
 
+// Free deletes ranges created by NewGlyphRanges.
+// Data of the ranges must not be used by any font afterwards.
+func (ranges GlyphRanges) Free() {
+	C.IggDeleteGlyphRanges(ranges.handle())
+}
+
 func NewFontGlyphRangesBuilder() FontGlyphRangesBuilder {
 	handle := C.IggNewFontGlyphRangesBuilder()
 	return FontGlyphRangesBuilder(handle)
@@ -23,6 +29,14 @@ func (builder FontGlyphRangesBuilder) handle() C.IggFontGlyphRangesBuilder {
 	return C.IggFontGlyphRangesBuilder(builder)
 }
 
+// AddChar adds a single character. Characters outside of the basic multilingual plane are ignored.
+func (builder FontGlyphRangesBuilder) AddChar(c rune) {
+	if c < 0 || c > 0xFFFF {
+		return
+	}
+	C.IggFontGlyphRangesBuilderAddChar(builder.handle(), C.ushort(c))
+}
+
 func (builder FontGlyphRangesBuilder) AddText(text string) {
 	textArg, textFin := wrapString(text)
 	defer textFin()
@@ -0,0 +1,8 @@
+#include "imguiWrappedHeader.h"
+#include "FontWrapper.h"
+
+void iggFontSetScale(IggFont handle, float scale)
+{
+   ImFont *font = reinterpret_cast<ImFont *>(handle);
+   font->Scale = scale;
+}
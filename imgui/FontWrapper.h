@@ -0,0 +1,14 @@
+#pragma once
+
+#include "imguiWrapperTypes.h"
+
+#ifdef __cplusplus
+extern "C"
+{
+#endif
+
+extern void iggFontSetScale(IggFont handle, float scale);
+
+#ifdef __cplusplus
+}
+#endif
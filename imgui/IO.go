@@ -84,6 +84,33 @@ func (io IO) GetMouseDelta() Vec2 {
 	return delta
 }
 
+// GetMouseWheel returns the mouse wheel movement of this frame.
+func (io IO) GetMouseWheel() (horizontal, vertical float32) {
+	var h, v C.float
+	C.iggIoGetMouseWheel(io.handle, &h, &v)
+	return float32(h), float32(v)
+}
+
+// KeyCtrlPressed returns true if either control key is down in this frame.
+func (io IO) KeyCtrlPressed() bool {
+	return C.iggIoKeyCtrlPressed(io.handle) != 0
+}
+
+// KeyShiftPressed returns true if either shift key is down in this frame.
+func (io IO) KeyShiftPressed() bool {
+	return C.iggIoKeyShiftPressed(io.handle) != 0
+}
+
+// KeyAltPressed returns true if either alt key is down in this frame.
+func (io IO) KeyAltPressed() bool {
+	return C.iggIoKeyAltPressed(io.handle) != 0
+}
+
+// KeySuperPressed returns true if either super key is down in this frame.
+func (io IO) KeySuperPressed() bool {
+	return C.iggIoKeySuperPressed(io.handle) != 0
+}
+
 // SetDeltaTime sets the time elapsed since last frame, in seconds.
 func (io IO) SetDeltaTime(value float32) {
 	C.iggIoSetDeltaTime(io.handle, C.float(value))
@@ -94,6 +121,11 @@ func (io IO) SetFontGlobalScale(value float32) {
 	C.iggIoSetFontGlobalScale(io.handle, C.float(value))
 }
 
+// SetFontDefault sets the font to use on NewFrame(). Use DefaultFont to fall back to the first font of the atlas.
+func (io IO) SetFontDefault(font Font) {
+	C.iggIoSetFontDefault(io.handle, font.handle())
+}
+
 // KeyPress sets the KeysDown flag.
 func (io IO) KeyPress(key int) {
 	C.iggIoKeyPress(io.handle, C.int(key))
@@ -59,6 +59,37 @@ void iggIoGetMouseDelta(IggIO handle, IggVec2 *value)
   exportValue(*value, io->MouseDelta);
 }
 
+void iggIoGetMouseWheel(IggIO handle, float *horizontal, float *vertical)
+{
+  ImGuiIO *io = reinterpret_cast<ImGuiIO *>(handle);
+  *horizontal = io->MouseWheelH;
+  *vertical = io->MouseWheel;
+}
+
+IggBool iggIoKeyCtrlPressed(IggIO handle)
+{
+  ImGuiIO *io = reinterpret_cast<ImGuiIO *>(handle);
+  return io->KeyCtrl ? 1 : 0;
+}
+
+IggBool iggIoKeyShiftPressed(IggIO handle)
+{
+  ImGuiIO *io = reinterpret_cast<ImGuiIO *>(handle);
+  return io->KeyShift ? 1 : 0;
+}
+
+IggBool iggIoKeyAltPressed(IggIO handle)
+{
+  ImGuiIO *io = reinterpret_cast<ImGuiIO *>(handle);
+  return io->KeyAlt ? 1 : 0;
+}
+
+IggBool iggIoKeySuperPressed(IggIO handle)
+{
+  ImGuiIO *io = reinterpret_cast<ImGuiIO *>(handle);
+  return io->KeySuper ? 1 : 0;
+}
+
 void iggIoSetDeltaTime(IggIO handle, float value)
 {
    ImGuiIO *io = reinterpret_cast<ImGuiIO *>(handle);
@@ -71,6 +102,12 @@ void iggIoSetFontGlobalScale(IggIO handle, float value)
    io->FontGlobalScale = value;
 }
 
+void iggIoSetFontDefault(IggIO handle, IggFont font)
+{
+   ImGuiIO *io = reinterpret_cast<ImGuiIO *>(handle);
+   io->FontDefault = reinterpret_cast<ImFont *>(font);
+}
+
 
 IggBool iggIoGetMouseDrawCursor(IggIO handle)
 {
@@ -18,8 +18,14 @@ extern "C"
     extern void iggIoSetMouseButtonDown(IggIO handle, int index, IggBool value);
     extern void iggIoAddMouseWheelDelta(IggIO handle, float x, float y);
     extern void iggIoGetMouseDelta(IggIO handle, IggVec2 *delta);
+    extern void iggIoGetMouseWheel(IggIO handle, float *horizontal, float *vertical);
+    extern IggBool iggIoKeyCtrlPressed(IggIO handle);
+    extern IggBool iggIoKeyShiftPressed(IggIO handle);
+    extern IggBool iggIoKeyAltPressed(IggIO handle);
+    extern IggBool iggIoKeySuperPressed(IggIO handle);
     extern void iggIoSetDeltaTime(IggIO handle, float value);
     extern void iggIoSetFontGlobalScale(IggIO handle, float value);
+    extern void iggIoSetFontDefault(IggIO handle, IggFont font);
 
     extern IggBool iggIoGetMouseDrawCursor(IggIO handle);
     extern void iggIoSetMouseDrawCursor(IggIO handle, IggBool value);
@@ -36,6 +36,9 @@ type GLFW struct {
 	mouseCursors map[int]*glfw.Cursor
 
 	sizeChangeCallback func(int, int)
+
+	contentScale               float32
+	contentScaleChangeCallback func(float32)
 }
 
 // NewGLFW attempts to initialize a GLFW context.
@@ -68,6 +71,7 @@ func NewGLFW(io IO, title string, width, height int, resizable bool) (*GLFW, err
 		imguiIO: io,
 		window:  window,
 	}
+	platform.contentScale, _ = window.GetContentScale()
 	platform.setKeyMapping()
 	platform.installCallbacks()
 
@@ -183,6 +187,15 @@ func (platform *GLFW) Update() {
 	glfw.PostEmptyEvent()
 }
 
+// ContentScale returns the content scale of the monitor the window is on.
+func (platform *GLFW) ContentScale() float32 {
+	return platform.contentScale
+}
+
+func (platform *GLFW) SetContentScaleChangeCallback(cb func(float32)) {
+	platform.contentScaleChangeCallback = cb
+}
+
 func (platform *GLFW) updateMouseCursor() {
 	io := platform.imguiIO
 	if (io.GetConfigFlags()&ConfigFlagNoMouseCursorChange) == 1 || platform.window.GetInputMode(glfw.CursorMode) == glfw.CursorDisabled {
@@ -233,6 +246,8 @@ func (platform *GLFW) installCallbacks() {
 	platform.window.SetKeyCallback(platform.keyChange)
 	platform.window.SetCharCallback(platform.charChange)
 	platform.window.SetSizeCallback(platform.sizeChange)
+	platform.window.SetContentScaleCallback(platform.contentScaleChange)
+	platform.window.SetPosCallback(platform.posChange)
 }
 
 var glfwButtonIndexByID = map[glfw.MouseButton]int{
@@ -256,6 +271,44 @@ func (platform *GLFW) sizeChange(window *glfw.Window, width, height int) {
 	}
 }
 
+func (platform *GLFW) contentScaleChange(window *glfw.Window, x, y float32) {
+	platform.setContentScale(x)
+}
+
+// posChange looks up the monitor the window has been moved to, because not every
+// system reports content scale changes of windows.
+func (platform *GLFW) posChange(window *glfw.Window, xpos, ypos int) {
+	width, height := window.GetSize()
+	centerX, centerY := xpos+width/2, ypos+height/2
+
+	for _, monitor := range glfw.GetMonitors() {
+		mode := monitor.GetVideoMode()
+		if mode == nil {
+			continue
+		}
+
+		mx, my := monitor.GetPos()
+		if centerX >= mx && centerX < mx+mode.Width && centerY >= my && centerY < my+mode.Height {
+			scale, _ := monitor.GetContentScale()
+			platform.setContentScale(scale)
+			return
+		}
+	}
+}
+
+func (platform *GLFW) setContentScale(scale float32) {
+	if scale <= 0 || scale == platform.contentScale {
+		return
+	}
+
+	platform.contentScale = scale
+	platform.imguiIO.SetFrameCountSinceLastInput(0)
+
+	if platform.contentScaleChangeCallback != nil {
+		platform.contentScaleChangeCallback(scale)
+	}
+}
+
 func (platform *GLFW) mouseButtonChange(window *glfw.Window, rawButton glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
 	platform.imguiIO.SetFrameCountSinceLastInput(0)
 
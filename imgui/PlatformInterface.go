@@ -19,4 +19,9 @@ type Platform interface {
 	SetSizeChangeCallback(func(width, height int))
 	// Force Update
 	Update()
+	// ContentScale returns the ratio between the current DPI and the platform's default DPI
+	// of the monitor the window is on.
+	ContentScale() float32
+	// Set content scale change callback, which is called when the window moves to a monitor with a different scale
+	SetContentScaleChangeCallback(func(scale float32))
 }
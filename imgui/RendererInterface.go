@@ -8,8 +8,12 @@ type Renderer interface {
 	PreRender(clearColor [4]float32)
 	// Render draws the provided imgui draw data.
 	Render(displaySize [2]float32, framebufferSize [2]float32, drawData DrawData)
-	// Load image and return the TextureID
-	LoadImage(image *image.RGBA) (TextureID, error)
+	// RefreshFontTexture releases the font texture and uploads the (rebuilt) font atlas of the current context again.
+	RefreshFontTexture()
+	// Load image and return the TextureID, the texture has to be sampled as described by options
+	LoadImage(image *image.RGBA, options TextureOptions) (TextureID, error)
+	// Update the pixels of an image, the bounds of image locate the pixels in the texture
+	UpdateImage(textureId TextureID, image *image.RGBA) error
 	// Release image
 	ReleaseImage(textureId TextureID)
 	// Dispose
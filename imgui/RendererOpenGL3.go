@@ -297,7 +297,7 @@ func (renderer *OpenGL3) createFontsTexture() {
 	// Build texture atlas
 	io := CurrentIO()
 	fonts := io.Fonts()
-	if EnableFreeType {
+	if EnableFreeType && !fonts.IsBuilt() {
 		fonts.AddFontDefault()
 		err := fonts.BuildWithFreeType()
 		if err != nil {
@@ -324,6 +324,21 @@ func (renderer *OpenGL3) createFontsTexture() {
 	gl.BindTexture(gl.TEXTURE_2D, uint32(lastTexture))
 }
 
+func (renderer *OpenGL3) destroyFontsTexture() {
+	if renderer.fontTexture != 0 {
+		gl.DeleteTextures(1, &renderer.fontTexture)
+		CurrentIO().Fonts().SetTextureID(0)
+		renderer.fontTexture = 0
+	}
+}
+
+// RefreshFontTexture releases the font texture and uploads the font atlas again.
+// Call it between frames after the atlas was changed.
+func (renderer *OpenGL3) RefreshFontTexture() {
+	renderer.destroyFontsTexture()
+	renderer.createFontsTexture()
+}
+
 func (renderer *OpenGL3) invalidateDeviceObjects() {
 	if renderer.vboHandle != 0 {
 		gl.DeleteBuffers(1, &renderer.vboHandle)
@@ -355,16 +370,12 @@ func (renderer *OpenGL3) invalidateDeviceObjects() {
 	}
 	renderer.shaderHandle = 0
 
-	if renderer.fontTexture != 0 {
-		gl.DeleteTextures(1, &renderer.fontTexture)
-		CurrentIO().Fonts().SetTextureID(0)
-		renderer.fontTexture = 0
-	}
+	renderer.destroyFontsTexture()
 }
 
 // Load image and return the TextureID
-func (renderer *OpenGL3) LoadImage(image *image.RGBA) (TextureID, error) {
-	texture, err := renderer.createImageTexture(image)
+func (renderer *OpenGL3) LoadImage(image *image.RGBA, options TextureOptions) (TextureID, error) {
+	texture, err := renderer.createImageTexture(image, options)
 	if err != nil {
 		return 0, err
 	}
@@ -372,23 +383,81 @@ func (renderer *OpenGL3) LoadImage(image *image.RGBA) (TextureID, error) {
 	return texture, nil
 }
 
+// Update the pixels of an image, the bounds of image locate the pixels in the texture
+func (renderer *OpenGL3) UpdateImage(textureId TextureID, img *image.RGBA) error {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil
+	}
+
+	var lastTexture int32
+	gl.GetIntegerv(gl.TEXTURE_BINDING_2D, &lastTexture)
+	gl.BindTexture(gl.TEXTURE_2D, uint32(textureId))
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(img.Stride/4))
+	gl.TexSubImage2D(gl.TEXTURE_2D, 0, int32(bounds.Min.X), int32(bounds.Min.Y), int32(bounds.Dx()), int32(bounds.Dy()), gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, 0)
+
+	var minFilter int32
+	gl.GetTexParameteriv(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, &minFilter)
+	if minFilter != gl.LINEAR && minFilter != gl.NEAREST {
+		gl.GenerateMipmap(gl.TEXTURE_2D)
+	}
+
+	gl.BindTexture(gl.TEXTURE_2D, uint32(lastTexture))
+
+	if glErr := gl.GetError(); glErr != gl.NO_ERROR {
+		return fmt.Errorf("failed to update texture: 0x%x", glErr)
+	}
+	return nil
+}
+
 func (renderer *OpenGL3) ReleaseImage(textureId TextureID) {
 	gl.BindTexture(gl.TEXTURE_2D, 0)
 	handle := uint32(textureId)
 	gl.DeleteTextures(1, &handle)
 }
 
-func (renderer *OpenGL3) createImageTexture(img *image.RGBA) (TextureID, error) {
+func glTextureFilter(filter TextureFilter, mipmaps bool) int32 {
+	switch {
+	case filter == TextureFilterNearest && mipmaps:
+		return gl.NEAREST_MIPMAP_NEAREST
+	case filter == TextureFilterNearest:
+		return gl.NEAREST
+	case mipmaps:
+		return gl.LINEAR_MIPMAP_LINEAR
+	default:
+		return gl.LINEAR
+	}
+}
+
+func glTextureWrap(wrap TextureWrap) int32 {
+	switch wrap {
+	case TextureWrapRepeat:
+		return gl.REPEAT
+	case TextureWrapMirroredRepeat:
+		return gl.MIRRORED_REPEAT
+	default:
+		return gl.CLAMP_TO_EDGE
+	}
+}
+
+func (renderer *OpenGL3) createImageTexture(img *image.RGBA, options TextureOptions) (TextureID, error) {
 	// Upload texture to graphics system
 	var lastTexture int32
 	var handle uint32
 	gl.GetIntegerv(gl.TEXTURE_BINDING_2D, &lastTexture)
 	gl.GenTextures(1, &handle)
 	gl.BindTexture(gl.TEXTURE_2D, handle)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR) // minification filter
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR) // magnification filter
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, glTextureFilter(options.MinFilter, options.Mipmaps)) // minification filter
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, glTextureFilter(options.MagFilter, false))           // magnification filter
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, glTextureWrap(options.WrapU))
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, glTextureWrap(options.WrapV))
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(img.Stride/4))
 	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, int32(img.Bounds().Dx()), int32(img.Bounds().Dy()), 0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
-	gl.GenerateMipmap(gl.TEXTURE_2D)
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, 0)
+	if options.Mipmaps {
+		gl.GenerateMipmap(gl.TEXTURE_2D)
+	}
 
 	// Restore state
 	gl.BindTexture(gl.TEXTURE_2D, uint32(lastTexture))
@@ -127,6 +127,15 @@ func (style Style) ItemInnerSpacing() Vec2 {
 	return value
 }
 
+// ItemSpacing is the horizontal and vertical spacing between widgets or lines.
+func (style Style) ItemSpacing() Vec2 {
+	var value Vec2
+	valueArg, valueFin := value.wrapped()
+	C.iggStyleGetItemSpacing(style.handle(), valueArg)
+	valueFin()
+	return value
+}
+
 func (style Style) WindowPadding() Vec2 {
 	var value Vec2
 	valueArg, valueFin := value.wrapped()
@@ -135,6 +144,11 @@ func (style Style) WindowPadding() Vec2 {
 	return value
 }
 
+// ScrollbarSize is the width of vertical scrollbars and the height of horizontal ones.
+func (style Style) ScrollbarSize() float32 {
+	return float32(C.iggStyleGetScrollbarSize(style.handle()))
+}
+
 // SetColor sets a color value of the UI style.
 func (style Style) SetColor(id StyleColorID, value Vec4) {
 	valueArg, _ := value.wrapped()
@@ -158,3 +172,20 @@ func (style Style) GetColor(id StyleColorID) Vec4 {
 func (style Style) ScaleAllSizes(scale float32) {
 	C.iggStyleScaleAllSizes(style.handle(), C.float(scale))
 }
+
+// Copy returns a copy of the style which isn't used by any context, e.g. to keep the unscaled sizes.
+// It has to be freed with Destroy.
+func (style Style) Copy() Style {
+	return Style(C.iggStyleCopy(style.handle()))
+}
+
+// Destroy frees a style returned by Copy.
+func (style Style) Destroy() {
+	C.iggStyleDelete(style.handle())
+}
+
+// ScaleAllSizesFrom sets all sizes to the ones of unscaled multiplied by scale, rounded like ScaleAllSizes does.
+// Unlike calling ScaleAllSizes repeatedly, changing the scale this way isn't lossy. Colors and other settings are kept.
+func (style Style) ScaleAllSizesFrom(unscaled Style, scale float32) {
+	C.iggStyleScaleAllSizesFrom(style.handle(), unscaled.handle(), C.float(scale))
+}
@@ -8,12 +8,24 @@ void iggStyleGetItemInnerSpacing(IggGuiStyle handle, IggVec2 *value)
    exportValue(*value, style->ItemInnerSpacing);
 }
 
+void iggStyleGetItemSpacing(IggGuiStyle handle, IggVec2 *value)
+{
+   ImGuiStyle *style = reinterpret_cast<ImGuiStyle *>(handle);
+   exportValue(*value, style->ItemSpacing);
+}
+
 void iggStyleGetWindowPadding(IggGuiStyle handle, IggVec2 *value)
 {
    ImGuiStyle *style = reinterpret_cast<ImGuiStyle *>(handle);
    exportValue(*value, style->WindowPadding);
 }
 
+float iggStyleGetScrollbarSize(IggGuiStyle handle)
+{
+   ImGuiStyle *style = reinterpret_cast<ImGuiStyle *>(handle);
+   return style->ScrollbarSize;
+}
+
 void iggStyleSetColor(IggGuiStyle handle, int colorID, IggVec4 const *value)
 {
    ImGuiStyle *style = reinterpret_cast<ImGuiStyle *>(handle);
@@ -37,3 +49,43 @@ void iggStyleScaleAllSizes(IggGuiStyle handle, float scale)
    ImGuiStyle *style = reinterpret_cast<ImGuiStyle *>(handle);
    style->ScaleAllSizes(scale);
 }
+
+IggGuiStyle iggStyleCopy(IggGuiStyle handle)
+{
+   ImGuiStyle *style = reinterpret_cast<ImGuiStyle *>(handle);
+   return reinterpret_cast<IggGuiStyle>(new ImGuiStyle(*style));
+}
+
+void iggStyleDelete(IggGuiStyle handle)
+{
+   delete reinterpret_cast<ImGuiStyle *>(handle);
+}
+
+void iggStyleScaleAllSizesFrom(IggGuiStyle handle, IggGuiStyle unscaled, float scale)
+{
+   ImGuiStyle *style = reinterpret_cast<ImGuiStyle *>(handle);
+   ImGuiStyle scaled = *reinterpret_cast<ImGuiStyle *>(unscaled);
+   scaled.ScaleAllSizes(scale);
+
+   // Only the sizes ScaleAllSizes changes are taken, colors and other settings are kept.
+   style->WindowPadding = scaled.WindowPadding;
+   style->WindowRounding = scaled.WindowRounding;
+   style->WindowMinSize = scaled.WindowMinSize;
+   style->ChildRounding = scaled.ChildRounding;
+   style->PopupRounding = scaled.PopupRounding;
+   style->FramePadding = scaled.FramePadding;
+   style->FrameRounding = scaled.FrameRounding;
+   style->ItemSpacing = scaled.ItemSpacing;
+   style->ItemInnerSpacing = scaled.ItemInnerSpacing;
+   style->TouchExtraPadding = scaled.TouchExtraPadding;
+   style->IndentSpacing = scaled.IndentSpacing;
+   style->ColumnsMinSpacing = scaled.ColumnsMinSpacing;
+   style->ScrollbarSize = scaled.ScrollbarSize;
+   style->ScrollbarRounding = scaled.ScrollbarRounding;
+   style->GrabMinSize = scaled.GrabMinSize;
+   style->GrabRounding = scaled.GrabRounding;
+   style->TabRounding = scaled.TabRounding;
+   style->DisplayWindowPadding = scaled.DisplayWindowPadding;
+   style->DisplaySafeAreaPadding = scaled.DisplaySafeAreaPadding;
+   style->MouseCursorScale = scaled.MouseCursorScale;
+}
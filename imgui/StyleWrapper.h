@@ -9,14 +9,24 @@ extern "C"
 
 extern void iggStyleGetItemInnerSpacing(IggGuiStyle handle, IggVec2 *value);
 
+extern void iggStyleGetItemSpacing(IggGuiStyle handle, IggVec2 *value);
+
 extern void iggStyleGetWindowPadding(IggGuiStyle handle, IggVec2 *value);
 
+extern float iggStyleGetScrollbarSize(IggGuiStyle handle);
+
 extern void iggStyleSetColor(IggGuiStyle handle, int index, IggVec4 const *color);
 
 extern void iggStyleGetColor(IggGuiStyle handle, int index, IggVec4 *color);
 
 extern void iggStyleScaleAllSizes(IggGuiStyle handle, float scale);
 
+extern IggGuiStyle iggStyleCopy(IggGuiStyle handle);
+
+extern void iggStyleDelete(IggGuiStyle handle);
+
+extern void iggStyleScaleAllSizesFrom(IggGuiStyle handle, IggGuiStyle unscaled, float scale);
+
 #ifdef __cplusplus
 }
 #endif
@@ -0,0 +1,34 @@
+package imgui_test
+
+import (
+	"testing"
+
+	"github.com/AllenDang/giu/imgui"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleScaleAllSizesFrom(t *testing.T) {
+	context := imgui.CreateContext(nil)
+	defer context.Destroy()
+
+	style := imgui.CurrentStyle()
+	unscaled := style.Copy()
+	defer unscaled.Destroy()
+	spacing, padding := style.ItemSpacing(), style.WindowPadding()
+
+	// Moving between monitors back and forth doesn't shrink the sizes.
+	for i := 0; i < 5; i++ {
+		style.ScaleAllSizesFrom(unscaled, 1.5)
+		style.ScaleAllSizesFrom(unscaled, 1)
+	}
+	assert.Equal(t, spacing, style.ItemSpacing())
+	assert.Equal(t, padding, style.WindowPadding())
+
+	color := imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1}
+	style.SetColor(imgui.StyleColorText, color)
+	style.ScaleAllSizesFrom(unscaled, 2)
+	assert.Equal(t, imgui.Vec2{X: spacing.X * 2, Y: spacing.Y * 2}, style.ItemSpacing())
+	assert.Equal(t, color, style.GetColor(imgui.StyleColorText), "colors expected to be kept")
+	assert.NotEqual(t, color, unscaled.GetColor(imgui.StyleColorText), "copy expected to be independent")
+}
@@ -0,0 +1,39 @@
+package imgui
+
+// TextureFilter describes how texels are sampled when a texture is drawn scaled.
+type TextureFilter int
+
+const (
+	// TextureFilterLinear interpolates between neighbouring texels.
+	TextureFilterLinear TextureFilter = 0
+	// TextureFilterNearest takes the nearest texel, e.g. for pixel art.
+	TextureFilterNearest TextureFilter = 1
+)
+
+// TextureWrap describes how texture coordinates outside of [0, 1] are handled.
+type TextureWrap int
+
+const (
+	// TextureWrapClamp clamps coordinates to the edge texels.
+	TextureWrapClamp TextureWrap = 0
+	// TextureWrapRepeat repeats the texture, e.g. for tiled backgrounds.
+	TextureWrapRepeat TextureWrap = 1
+	// TextureWrapMirroredRepeat repeats the texture, mirroring every other repetition.
+	TextureWrapMirroredRepeat TextureWrap = 2
+)
+
+// TextureOptions describes how a texture is sampled.
+// The zero value uses linear filtering, clamped coordinates and no mipmaps.
+type TextureOptions struct {
+	// MinFilter is used when the texture is drawn smaller than its size.
+	MinFilter TextureFilter
+	// MagFilter is used when the texture is drawn larger than its size.
+	MagFilter TextureFilter
+	// WrapU handles horizontal texture coordinates outside of [0, 1].
+	WrapU TextureWrap
+	// WrapV handles vertical texture coordinates outside of [0, 1].
+	WrapV TextureWrap
+	// Mipmaps generates downscaled versions of the texture, for large images drawn small.
+	// MinFilter then applies between mipmap levels too.
+	Mipmaps bool
+}
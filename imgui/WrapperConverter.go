@@ -55,6 +55,19 @@ func wrapFloat(goValue *float32) (wrapped *C.float, finisher func()) {
 	return
 }
 
+func wrapDouble(goValue *float64) (wrapped *C.double, finisher func()) {
+	if goValue != nil {
+		cValue := C.double(*goValue)
+		wrapped = &cValue
+		finisher = func() {
+			*goValue = float64(cValue)
+		}
+	} else {
+		finisher = func() {}
+	}
+	return
+}
+
 func wrapString(value string) (wrapped *C.char, finisher func()) {
 	wrapped = C.CString(value)
 	finisher = func() { C.free(unsafe.Pointer(wrapped)) } // nolint: gas
@@ -63,3 +63,28 @@ func TestStringBufferResize(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapDouble(t *testing.T) {
+	tt := []float64{0, 1.5, -2.25, 1e300}
+
+	for _, tc := range tt {
+		td := tc
+		t.Run(fmt.Sprintf("<%g>", td), func(t *testing.T) {
+			value := td
+			wrapped, finisher := wrapDouble(&value)
+			require.NotNil(t, wrapped, "wrapped value expected")
+			assert.Equal(t, td, float64(*wrapped))
+
+			*wrapped /= 2
+			assert.Equal(t, td, value, "value changed before finisher")
+			finisher()
+			assert.Equal(t, td/2, value)
+		})
+	}
+}
+
+func TestWrapDoubleNil(t *testing.T) {
+	wrapped, finisher := wrapDouble(nil)
+	assert.Nil(t, wrapped)
+	assert.NotPanics(t, finisher)
+}
@@ -137,6 +137,26 @@ func WindowHeight() float32 {
 	return float32(C.iggWindowHeight())
 }
 
+// IsWindowFocusedV returns true if the current window is focused, flags are the FocusedFlags to apply.
+func IsWindowFocusedV(flags int) bool {
+	return C.iggIsWindowFocused(C.int(flags)) != 0
+}
+
+// IsWindowFocused calls IsWindowFocusedV(FocusedFlagsNone).
+func IsWindowFocused() bool {
+	return IsWindowFocusedV(FocusedFlagsNone)
+}
+
+// ScrollY returns the vertical scrolling amount of the current window, between 0 and the maximum scroll.
+func ScrollY() float32 {
+	return float32(C.iggGetScrollY())
+}
+
+// SetScrollY sets the vertical scrolling amount of the current window, it's applied in the next frame.
+func SetScrollY(scrollY float32) {
+	C.iggSetScrollY(C.float(scrollY))
+}
+
 // ContentRegionAvail returns the size of the content region that is available (based on the current cursor position).
 func ContentRegionAvail() Vec2 {
 	var value Vec2
@@ -510,6 +530,55 @@ func SliderInt(label string, value *int32, min, max int32) bool {
 	return SliderIntV(label, value, min, max, "%d")
 }
 
+// InputIntV creates an input field for integers with step buttons, a step of 0 hides the buttons.
+// stepFast is used while holding ctrl, flags are the InputTextFlags to apply.
+func InputIntV(label string, value *int32, step, stepFast int, flags int) bool {
+	labelArg, labelFin := wrapString(label)
+	defer labelFin()
+	valueArg, valueFin := wrapInt32(value)
+	defer valueFin()
+	return C.iggInputInt(labelArg, valueArg, C.int(step), C.int(stepFast), C.int(flags)) != 0
+}
+
+// InputInt calls InputIntV(label, value, 1, 100, 0).
+func InputInt(label string, value *int32) bool {
+	return InputIntV(label, value, 1, 100, 0)
+}
+
+// InputFloatV creates an input field for floats with step buttons, a step of 0 hides the buttons.
+// stepFast is used while holding ctrl, flags are the InputTextFlags to apply.
+func InputFloatV(label string, value *float32, step, stepFast float32, format string, flags int) bool {
+	labelArg, labelFin := wrapString(label)
+	defer labelFin()
+	valueArg, valueFin := wrapFloat(value)
+	defer valueFin()
+	formatArg, formatFin := wrapString(format)
+	defer formatFin()
+	return C.iggInputFloat(labelArg, valueArg, C.float(step), C.float(stepFast), formatArg, C.int(flags)) != 0
+}
+
+// InputFloat calls InputFloatV(label, value, 0, 0, "%.3f", 0).
+func InputFloat(label string, value *float32) bool {
+	return InputFloatV(label, value, 0, 0, "%.3f", 0)
+}
+
+// InputDoubleV creates an input field for doubles with step buttons, a step of 0 hides the buttons.
+// stepFast is used while holding ctrl, flags are the InputTextFlags to apply.
+func InputDoubleV(label string, value *float64, step, stepFast float64, format string, flags int) bool {
+	labelArg, labelFin := wrapString(label)
+	defer labelFin()
+	valueArg, valueFin := wrapDouble(value)
+	defer valueFin()
+	formatArg, formatFin := wrapString(format)
+	defer formatFin()
+	return C.iggInputDouble(labelArg, valueArg, C.double(step), C.double(stepFast), formatArg, C.int(flags)) != 0
+}
+
+// InputDouble calls InputDoubleV(label, value, 0, 0, "%.6f", 0).
+func InputDouble(label string, value *float64) bool {
+	return InputDoubleV(label, value, 0, 0, "%.6f", 0)
+}
+
 // InputTextV creates a text field for dynamic text input.
 //
 // Contrary to the original library, this wrapper does not limit the maximum number of possible characters.
@@ -785,8 +854,13 @@ func ListBoxV(label string, currentItem *int32, items []string, heightItems int)
 		defer itemDeleter()
 		argv[i] = itemArg
 	}
+	// An empty list box has no items to point to.
+	var argvArg **C.char
+	if itemsCount > 0 {
+		argvArg = &argv[0]
+	}
 
-	return C.iggListBoxV(labelArg, valueArg, &argv[0], C.int(itemsCount), C.int(heightItems)) != 0
+	return C.iggListBoxV(labelArg, valueArg, argvArg, C.int(itemsCount), C.int(heightItems)) != 0
 }
 
 // ListBox calls ListBoxV(label, currentItem, items, -1)
@@ -951,6 +1025,19 @@ func OpenPopup(id string) {
 	C.iggOpenPopup(idArg)
 }
 
+// BeginPopupV returns true if the popup opened with OpenPopup(name) is open, and starts appending to it.
+// Call EndPopup() only if BeginPopupV() returns true. flags are the WindowFlags to apply.
+func BeginPopupV(name string, flags int) bool {
+	nameArg, nameFin := wrapString(name)
+	defer nameFin()
+	return C.iggBeginPopup(nameArg, C.int(flags)) != 0
+}
+
+// BeginPopup calls BeginPopupV(name, 0).
+func BeginPopup(name string) bool {
+	return BeginPopupV(name, 0)
+}
+
 // BeginPopupModalV creates modal dialog (regular window with title bar, block interactions behind the modal window,
 // can't close the modal window by clicking outside).
 func BeginPopupModalV(name string, open *bool, flags int) bool {
@@ -1005,6 +1092,46 @@ func IsItemActive() bool {
 	return C.iggIsItemActive() != 0
 }
 
+// IsItemClickedV returns true if the last item was clicked with given mouse button (0=left, 1=right, 2=middle).
+func IsItemClickedV(mouseButton int) bool {
+	return C.iggIsItemClicked(C.int(mouseButton)) != 0
+}
+
+// IsItemClicked calls IsItemClickedV(0).
+func IsItemClicked() bool {
+	return IsItemClickedV(0)
+}
+
+// SetItemAllowOverlap allows the last item to be overlapped by a subsequent item.
+// Useful with invisible buttons and selectables that have widgets on top of them.
+func SetItemAllowOverlap() {
+	C.iggSetItemAllowOverlap()
+}
+
+// ItemRectMin returns the upper-left bounding rectangle of the last item, in screen space.
+func ItemRectMin() Vec2 {
+	var value Vec2
+	valueArg, valueFin := value.wrapped()
+	C.iggGetItemRectMin(valueArg)
+	valueFin()
+	return value
+}
+
+// ItemRectMax returns the lower-right bounding rectangle of the last item, in screen space.
+func ItemRectMax() Vec2 {
+	var value Vec2
+	valueArg, valueFin := value.wrapped()
+	C.iggGetItemRectMax(valueArg)
+	valueFin()
+	return value
+}
+
+// KeyIndex maps a Key* constant to the index of the key in the KeysDown array of IO, as set with IO.KeyMap().
+// The result can be passed to IsKeyDown(), IsKeyPressed() and IsKeyReleased().
+func KeyIndex(key int) int {
+	return int(C.iggGetKeyIndex(C.int(key)))
+}
+
 // IsKeyDown returns true if the corresponding key is currently being held down.
 func IsKeyDown(key int) bool {
 	return C.iggIsKeyDown(C.int(key)) != 0
@@ -1057,6 +1184,47 @@ func IsMouseDoubleClicked(button int) bool {
 	return C.iggIsMouseDoubleClicked(C.int(button)) != 0
 }
 
+// IsMouseDraggingV returns true if the mouse is dragging with given button held.
+// lockThreshold < 0 uses io.MouseDraggingThreshold.
+func IsMouseDraggingV(button int, lockThreshold float32) bool {
+	return C.iggIsMouseDragging(C.int(button), C.float(lockThreshold)) != 0
+}
+
+// IsMouseDragging calls IsMouseDraggingV(button, -1).
+func IsMouseDragging(button int) bool {
+	return IsMouseDraggingV(button, -1)
+}
+
+// MouseDragDeltaV returns the delta from the initial clicking position while the mouse button is pressed
+// or was just released. It is locked and returns 0 until the mouse moves past lockThreshold,
+// lockThreshold < 0 uses io.MouseDraggingThreshold.
+func MouseDragDeltaV(button int, lockThreshold float32) Vec2 {
+	var value Vec2
+	valueArg, valueFin := value.wrapped()
+	C.iggGetMouseDragDelta(valueArg, C.int(button), C.float(lockThreshold))
+	valueFin()
+	return value
+}
+
+// MouseDragDelta calls MouseDragDeltaV(button, -1).
+func MouseDragDelta(button int) Vec2 {
+	return MouseDragDeltaV(button, -1)
+}
+
+// ResetMouseDragDelta resets the drag delta of given mouse button.
+func ResetMouseDragDelta(button int) {
+	C.iggResetMouseDragDelta(C.int(button))
+}
+
+// MousePos returns the position of the mouse, in screen space.
+func MousePos() Vec2 {
+	var value Vec2
+	valueArg, valueFin := value.wrapped()
+	C.iggGetMousePos(valueArg)
+	valueFin()
+	return value
+}
+
 // Columns calls ColumnsV(1, "", false).
 func Columns() {
 	ColumnsV(1, "", false)
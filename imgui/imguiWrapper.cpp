@@ -122,6 +122,21 @@ float iggWindowHeight(void)
    return ImGui::GetWindowHeight();
 }
 
+IggBool iggIsWindowFocused(int flags)
+{
+   return ImGui::IsWindowFocused(flags) ? 1 : 0;
+}
+
+float iggGetScrollY(void)
+{
+   return ImGui::GetScrollY();
+}
+
+void iggSetScrollY(float scrollY)
+{
+   ImGui::SetScrollY(scrollY);
+}
+
 void iggContentRegionAvail(IggVec2 *size)
 {
    exportValue(*size, ImGui::GetContentRegionAvail());
@@ -343,6 +358,21 @@ IggBool iggSliderInt(char const *label, int *value, int minValue, int maxValue,
    return ImGui::SliderInt(label, value, minValue, maxValue, format) ? 1 : 0;
 }
 
+IggBool iggInputInt(char const *label, int *value, int step, int stepFast, int flags)
+{
+   return ImGui::InputInt(label, value, step, stepFast, flags) ? 1 : 0;
+}
+
+IggBool iggInputFloat(char const *label, float *value, float step, float stepFast, char const *format, int flags)
+{
+   return ImGui::InputFloat(label, value, step, stepFast, format, flags) ? 1 : 0;
+}
+
+IggBool iggInputDouble(char const *label, double *value, double step, double stepFast, char const *format, int flags)
+{
+   return ImGui::InputDouble(label, value, step, stepFast, format, flags) ? 1 : 0;
+}
+
 extern "C" int iggInputTextCallback(IggInputTextCallbackData data, int key);
 
 static int iggInputTextCallbackWrapper(ImGuiInputTextCallbackData *data)
@@ -566,6 +596,11 @@ void iggOpenPopup(char const *id)
    ImGui::OpenPopup(id);
 }
 
+IggBool iggBeginPopup(char const *name, int flags)
+{
+   return ImGui::BeginPopup(name, flags) ? 1 : 0;
+}
+
 IggBool iggBeginPopupModal(char const *name, IggBool *open, int flags)
 {
    BoolWrapper openArg(open);
@@ -597,11 +632,36 @@ IggBool iggIsItemActive()
   return ImGui::IsItemActive() ? 1 : 0;
 }
 
+IggBool iggIsItemClicked(int mouseButton)
+{
+   return ImGui::IsItemClicked(mouseButton) ? 1 : 0;
+}
+
+void iggSetItemAllowOverlap()
+{
+   ImGui::SetItemAllowOverlap();
+}
+
+void iggGetItemRectMin(IggVec2 *pos)
+{
+   exportValue(*pos, ImGui::GetItemRectMin());
+}
+
+void iggGetItemRectMax(IggVec2 *pos)
+{
+   exportValue(*pos, ImGui::GetItemRectMax());
+}
+
 IggBool iggIsKeyDown(int key)
 {
    return ImGui::IsKeyDown(key);
 }
 
+int iggGetKeyIndex(int key)
+{
+   return ImGui::GetKeyIndex(key);
+}
+
 IggBool iggIsKeyPressed(int key, IggBool repeat)
 {
    return ImGui::IsKeyPressed(key, repeat);
@@ -637,6 +697,26 @@ IggBool iggIsMouseDoubleClicked(int button)
    return ImGui::IsMouseDoubleClicked(button);
 }
 
+IggBool iggIsMouseDragging(int button, float lockThreshold)
+{
+   return ImGui::IsMouseDragging(button, lockThreshold) ? 1 : 0;
+}
+
+void iggGetMouseDragDelta(IggVec2 *value, int button, float lockThreshold)
+{
+   exportValue(*value, ImGui::GetMouseDragDelta(button, lockThreshold));
+}
+
+void iggResetMouseDragDelta(int button)
+{
+   ImGui::ResetMouseDragDelta(button);
+}
+
+void iggGetMousePos(IggVec2 *pos)
+{
+   exportValue(*pos, ImGui::GetMousePos());
+}
+
 void iggColumns(int count, char const *label, IggBool border)
 {
    ImGui::Columns(count, label, border);
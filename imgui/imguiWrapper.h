@@ -35,6 +35,9 @@ extern "C"
 	extern void iggWindowSize(IggVec2 *size);
 	extern float iggWindowWidth(void);
 	extern float iggWindowHeight(void);
+	extern IggBool iggIsWindowFocused(int flags);
+	extern float iggGetScrollY(void);
+	extern void iggSetScrollY(float scrollY);
 	extern void iggContentRegionAvail(IggVec2 *size);
 
 	extern void iggSetNextWindowPos(IggVec2 const *pos, int cond, IggVec2 const *pivot);
@@ -91,6 +94,10 @@ extern "C"
 
 	extern IggBool iggSliderInt(char const *label, int *value, int minValue, int maxValue, char const *format);
 
+	extern IggBool iggInputInt(char const *label, int *value, int step, int stepFast, int flags);
+	extern IggBool iggInputFloat(char const *label, float *value, float step, float stepFast, char const *format, int flags);
+	extern IggBool iggInputDouble(char const *label, double *value, double step, double stepFast, char const *format, int flags);
+
   extern IggBool iggInputText(char const* label, char* buf, unsigned int bufSize, int flags, int callbackKey);
   extern IggBool iggInputTextMultiline(char const* label, char* buf, unsigned int bufSize, IggVec2 const *size, int flags, int callbackKey);
 
@@ -142,6 +149,7 @@ extern "C"
 	extern IggBool iggMenuItem(char const *label, char const *shortcut, IggBool selected, IggBool enabled);
 
 	extern void iggOpenPopup(char const *id);
+	extern IggBool iggBeginPopup(char const *name, int flags);
 	extern IggBool iggBeginPopupModal(char const *name, IggBool *open, int flags);
 	extern IggBool iggBeginPopupContextItem(char const *label, int mouseButton);
 	extern void iggEndPopup(void);
@@ -149,7 +157,12 @@ extern "C"
 
 	extern IggBool iggIsItemHovered(int flags);
   extern IggBool iggIsItemActive();
+	extern IggBool iggIsItemClicked(int mouseButton);
+	extern void iggSetItemAllowOverlap();
+	extern void iggGetItemRectMin(IggVec2 *pos);
+	extern void iggGetItemRectMax(IggVec2 *pos);
 
+	extern int iggGetKeyIndex(int key);
 	extern IggBool iggIsKeyDown(int key);
 	extern IggBool iggIsKeyPressed(int key, IggBool repeat);
 	extern IggBool iggIsKeyReleased(int key);
@@ -158,6 +171,10 @@ extern "C"
 	extern IggBool iggIsMouseClicked(int button, IggBool repeat);
 	extern IggBool iggIsMouseReleased(int button);
 	extern IggBool iggIsMouseDoubleClicked(int button);
+	extern IggBool iggIsMouseDragging(int button, float lockThreshold);
+	extern void iggGetMouseDragDelta(IggVec2 *value, int button, float lockThreshold);
+	extern void iggResetMouseDragDelta(int button);
+	extern void iggGetMousePos(IggVec2 *pos);
 
 	extern void iggColumns(int count, char const *label, IggBool border);
 	extern void iggNextColumn();
@@ -12,3 +12,23 @@ func TestVersion(t *testing.T) {
 	version := imgui.Version()
 	assert.Equal(t, "1.74", version)
 }
+
+func TestListBoxWithoutItems(t *testing.T) {
+	context := imgui.CreateContext(nil)
+	defer context.Destroy()
+
+	io := imgui.CurrentIO()
+	io.SetDisplaySize(imgui.Vec2{X: 800, Y: 600})
+	io.Fonts().TextureDataAlpha8()
+
+	imgui.NewFrame()
+	imgui.Begin("window")
+	var selected int32
+	assert.NotPanics(t, func() {
+		assert.False(t, imgui.ListBox("empty", &selected, nil))
+		assert.False(t, imgui.ListBoxV("empty slice", &selected, []string{}, 3))
+	})
+	assert.Equal(t, int32(0), selected)
+	imgui.End()
+	imgui.Render()
+}